@@ -2,4 +2,4 @@
 
 set -eu
 
-deno run --allow-net --allow-env=DATA,SECURE_DATA,CONTEXT "$SCRIPT"
+deno run --allow-net --allow-env=DATA,SECURE_DATA,CONTEXT --allow-write=/dev/termination-log "$SCRIPT"
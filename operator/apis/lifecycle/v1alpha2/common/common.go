@@ -40,10 +40,13 @@ const (
 	StateUnknown     KeptnState = "Unknown"
 	StatePending     KeptnState = "Pending"
 	StateDeprecated  KeptnState = "Deprecated"
+	StateWarning     KeptnState = "Warning"
+	// StateSkipped is the state of a task that did not run because a task it depends on did not succeed
+	StateSkipped KeptnState = "Skipped"
 )
 
 func (k KeptnState) IsCompleted() bool {
-	return k == StateSucceeded || k == StateFailed || k == StateDeprecated
+	return k == StateSucceeded || k == StateFailed || k == StateDeprecated || k == StateSkipped
 }
 
 func (k KeptnState) IsSucceeded() bool {
@@ -62,6 +65,14 @@ func (k KeptnState) IsPending() bool {
 	return k == StatePending
 }
 
+func (k KeptnState) IsWarning() bool {
+	return k == StateWarning
+}
+
+func (k KeptnState) IsSkipped() bool {
+	return k == StateSkipped
+}
+
 type StatusSummary struct {
 	Total       int
 	Progressing int
@@ -70,6 +81,7 @@ type StatusSummary struct {
 	Pending     int
 	Unknown     int
 	Deprecated  int
+	Skipped     int
 }
 
 func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary {
@@ -86,12 +98,14 @@ func UpdateStatusSummary(status KeptnState, summary StatusSummary) StatusSummary
 		summary.Pending++
 	case StateUnknown:
 		summary.Unknown++
+	case StateSkipped:
+		summary.Skipped++
 	}
 	return summary
 }
 
 func (s StatusSummary) GetTotalCount() int {
-	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated
+	return s.Failed + s.Succeeded + s.Progressing + s.Pending + s.Unknown + s.Deprecated + s.Skipped
 }
 
 func GetOverallState(s StatusSummary) KeptnState {
@@ -123,6 +137,7 @@ const PreDeploymentCheckType CheckType = "pre"
 const PostDeploymentCheckType CheckType = "post"
 const PreDeploymentEvaluationCheckType CheckType = "pre-eval"
 const PostDeploymentEvaluationCheckType CheckType = "post-eval"
+const OnFailureCheckType CheckType = "on-failure"
 
 type KeptnMeters struct {
 	TaskCount          syncint64.Counter
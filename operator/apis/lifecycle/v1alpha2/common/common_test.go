@@ -28,6 +28,10 @@ func TestKeptnState_IsCompleted(t *testing.T) {
 			State: StateDeprecated,
 			Want:  true,
 		},
+		{
+			State: StateSkipped,
+			Want:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -120,39 +124,64 @@ func TestKeptnKeptnState_IsPending(t *testing.T) {
 	}
 }
 
+func TestKeptnState_IsSkipped(t *testing.T) {
+	tests := []struct {
+		State KeptnState
+		Want  bool
+	}{
+		{
+			State: StateFailed,
+			Want:  false,
+		},
+		{
+			State: StateSkipped,
+			Want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			require.Equal(t, tt.State.IsSkipped(), tt.Want)
+		})
+	}
+}
+
 func Test_UpdateStatusSummary(t *testing.T) {
-	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0}
+	emmptySummary := StatusSummary{0, 0, 0, 0, 0, 0, 0, 0}
 	tests := []struct {
 		State KeptnState
 		Want  StatusSummary
 	}{
 		{
 			State: StateProgressing,
-			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 1, 0, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateFailed,
-			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 		},
 		{
 			State: StateSucceeded,
-			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 1, 0, 0, 0, 0},
 		},
 		{
 			State: StatePending,
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 		},
 		{
 			State: "",
-			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 		},
 		{
 			State: StateUnknown,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 1, 0, 0},
 		},
 		{
 			State: StateDeprecated,
-			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 1, 0},
+		},
+		{
+			State: StateSkipped,
+			Want:  StatusSummary{0, 0, 0, 0, 0, 0, 0, 1},
 		},
 	}
 	for _, tt := range tests {
@@ -163,8 +192,8 @@ func Test_UpdateStatusSummary(t *testing.T) {
 }
 
 func Test_GetTotalCount(t *testing.T) {
-	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5}
-	require.Equal(t, summary.GetTotalCount(), 11)
+	summary := StatusSummary{2, 0, 2, 1, 0, 3, 5, 1}
+	require.Equal(t, summary.GetTotalCount(), 12)
 }
 
 func Test_GeOverallState(t *testing.T) {
@@ -175,37 +204,37 @@ func Test_GeOverallState(t *testing.T) {
 	}{
 		{
 			Name:    "failed",
-			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 0, 1, 0, 0, 0, 0, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "deprecated",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 0, 1, 0},
 			Want:    StateFailed,
 		},
 		{
 			Name:    "progressing",
-			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0},
+			Summary: StatusSummary{0, 1, 0, 0, 0, 0, 0, 0},
 			Want:    StateProgressing,
 		},
 		{
 			Name:    "pending",
-			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 1, 0, 0, 0},
 			Want:    StatePending,
 		},
 		{
 			Name:    "unknown",
-			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{0, 0, 0, 0, 0, 1, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "unknown totalcount",
-			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0},
+			Summary: StatusSummary{5, 0, 0, 0, 0, 1, 0, 0},
 			Want:    StateUnknown,
 		},
 		{
 			Name:    "succeeded",
-			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0},
+			Summary: StatusSummary{1, 0, 0, 1, 0, 0, 0, 0},
 			Want:    StateSucceeded,
 		},
 	}
@@ -0,0 +1,245 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TargetOperator is a comparison operator that can be used in an evaluation target
+type TargetOperator string
+
+const (
+	OperatorGreaterThan        TargetOperator = ">"
+	OperatorGreaterThanOrEqual TargetOperator = ">="
+	OperatorLessThan           TargetOperator = "<"
+	OperatorLessThanOrEqual    TargetOperator = "<="
+	OperatorEqual              TargetOperator = "=="
+	OperatorNotEqual           TargetOperator = "!="
+)
+
+const targetOr = "||"
+const targetAnd = "&&"
+
+// operators are ordered so that two-character operators are matched before their one-character prefixes
+var targetOperators = []TargetOperator{
+	OperatorGreaterThanOrEqual,
+	OperatorLessThanOrEqual,
+	OperatorEqual,
+	OperatorNotEqual,
+	OperatorGreaterThan,
+	OperatorLessThan,
+}
+
+// EvaluationTarget is the parsed form of an objective's evaluation target.
+// It is a disjunction (||) of conjunctions (&&) of single comparisons, ranges are expanded into two comparisons.
+// Numbers with a % suffix are relative to a baseline, e.g. the value of a previous version, and must be resolved
+// with ResolveBaseline before the target can be fulfilled.
+//
+// Examples of valid targets:
+//
+//	>10
+//	<=0.5
+//	!=0
+//	[100,200]          // 100 <= value <= 200
+//	(100,200]          // 100 <  value <= 200
+//	>=100 && <200
+//	<10 || >100 && <=150
+//	<=+10%             // value <= baseline * 1.1
+//	[-5%,+5%]          // baseline * 0.95 <= value <= baseline * 1.05
+type EvaluationTarget struct {
+	anyOf [][]TargetCondition
+}
+
+// TargetCondition is a single comparison of a value against a fixed number, or against a change in percent of
+// a baseline if Relative is set
+type TargetCondition struct {
+	Operator TargetOperator
+	Value    float64
+	Relative bool
+}
+
+// ParseEvaluationTarget parses the given target and returns an error if it does not follow the target grammar
+func ParseEvaluationTarget(target string) (EvaluationTarget, error) {
+	result := EvaluationTarget{}
+	if strings.TrimSpace(target) == "" {
+		return result, fmt.Errorf("empty evaluation target")
+	}
+	for _, orPart := range strings.Split(target, targetOr) {
+		var allOf []TargetCondition
+		for _, andPart := range strings.Split(orPart, targetAnd) {
+			conditions, err := parseTargetTerm(strings.TrimSpace(andPart))
+			if err != nil {
+				return EvaluationTarget{}, fmt.Errorf("invalid evaluation target %q: %w", target, err)
+			}
+			allOf = append(allOf, conditions...)
+		}
+		result.anyOf = append(result.anyOf, allOf)
+	}
+	return result, nil
+}
+
+// IsRelative returns true if at least one condition of the target is relative to a baseline
+func (t EvaluationTarget) IsRelative() bool {
+	for _, allOf := range t.anyOf {
+		for _, c := range allOf {
+			if c.Relative {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveBaseline returns a copy of the target in which all relative conditions are replaced by absolute ones
+func (t EvaluationTarget) ResolveBaseline(baseline float64) EvaluationTarget {
+	result := EvaluationTarget{}
+	for _, allOf := range t.anyOf {
+		resolved := make([]TargetCondition, 0, len(allOf))
+		for _, c := range allOf {
+			if c.Relative {
+				c = TargetCondition{Operator: c.Operator, Value: baseline + math.Abs(baseline)*c.Value/100}
+			}
+			resolved = append(resolved, c)
+		}
+		result.anyOf = append(result.anyOf, resolved)
+	}
+	return result
+}
+
+// DropRelative returns a copy of the target without relative conditions, it is used when there is no baseline
+// to compare with. Conjunctions that only consist of relative conditions are left out, so that they are not
+// fulfilled by any value, e.g. <=+10% is never fulfilled and <=+10% || <5 is only fulfilled by values below 5.
+func (t EvaluationTarget) DropRelative() EvaluationTarget {
+	result := EvaluationTarget{}
+	for _, allOf := range t.anyOf {
+		absolute := make([]TargetCondition, 0, len(allOf))
+		for _, c := range allOf {
+			if !c.Relative {
+				absolute = append(absolute, c)
+			}
+		}
+		if len(absolute) > 0 {
+			result.anyOf = append(result.anyOf, absolute)
+		}
+	}
+	return result
+}
+
+// IsFulfilledBy returns true if the given value satisfies the target.
+// NaN never satisfies a target, neither does an unresolved relative condition.
+func (t EvaluationTarget) IsFulfilledBy(value float64) bool {
+	if math.IsNaN(value) {
+		return false
+	}
+	for _, allOf := range t.anyOf {
+		if allConditionsFulfilled(allOf, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func allConditionsFulfilled(conditions []TargetCondition, value float64) bool {
+	for _, c := range conditions {
+		if !c.IsFulfilledBy(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFulfilledBy returns true if the given value satisfies the condition
+func (c TargetCondition) IsFulfilledBy(value float64) bool {
+	if c.Relative {
+		return false
+	}
+	switch c.Operator {
+	case OperatorGreaterThan:
+		return value > c.Value
+	case OperatorGreaterThanOrEqual:
+		return value >= c.Value
+	case OperatorLessThan:
+		return value < c.Value
+	case OperatorLessThanOrEqual:
+		return value <= c.Value
+	case OperatorEqual:
+		return value == c.Value
+	case OperatorNotEqual:
+		return value != c.Value
+	default:
+		return false
+	}
+}
+
+func parseTargetTerm(term string) ([]TargetCondition, error) {
+	if term == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+	if strings.HasPrefix(term, "[") || strings.HasPrefix(term, "(") {
+		return parseTargetRange(term)
+	}
+	for _, op := range targetOperators {
+		if strings.HasPrefix(term, string(op)) {
+			value, relative, err := parseTargetNumber(term[len(op):])
+			if err != nil {
+				return nil, err
+			}
+			return []TargetCondition{{Operator: op, Value: value, Relative: relative}}, nil
+		}
+	}
+	return nil, fmt.Errorf("condition %q does not start with a valid operator", term)
+}
+
+func parseTargetRange(term string) ([]TargetCondition, error) {
+	lowerOp := OperatorGreaterThanOrEqual
+	if term[0] == '(' {
+		lowerOp = OperatorGreaterThan
+	}
+	upperOp := OperatorLessThanOrEqual
+	switch term[len(term)-1] {
+	case ']':
+	case ')':
+		upperOp = OperatorLessThan
+	default:
+		return nil, fmt.Errorf("range %q is not closed with ']' or ')'", term)
+	}
+
+	bounds := strings.Split(term[1:len(term)-1], ",")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("range %q must have exactly two bounds", term)
+	}
+	lower, lowerRelative, err := parseTargetNumber(bounds[0])
+	if err != nil {
+		return nil, err
+	}
+	upper, upperRelative, err := parseTargetNumber(bounds[1])
+	if err != nil {
+		return nil, err
+	}
+	if lowerRelative != upperRelative {
+		return nil, fmt.Errorf("bounds of range %q must both be either absolute or relative", term)
+	}
+	if lower > upper {
+		return nil, fmt.Errorf("lower bound of range %q is greater than its upper bound", term)
+	}
+	return []TargetCondition{
+		{Operator: lowerOp, Value: lower, Relative: lowerRelative},
+		{Operator: upperOp, Value: upper, Relative: upperRelative},
+	}, nil
+}
+
+// parseTargetNumber parses an absolute number, or a change in percent if the number has a % suffix
+func parseTargetNumber(s string) (float64, bool, error) {
+	s = strings.TrimSpace(s)
+	relative := strings.HasSuffix(s, "%")
+	value, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%q is not a valid number", s)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, fmt.Errorf("%q is not a finite number", s)
+	}
+	return value, relative, nil
+}
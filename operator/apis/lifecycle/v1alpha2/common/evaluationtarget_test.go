@@ -0,0 +1,149 @@
+package common
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvaluationTarget(t *testing.T) {
+	tests := []struct {
+		target  string
+		wantErr bool
+	}{
+		{target: ">10"},
+		{target: "< 10"},
+		{target: ">=10"},
+		{target: "<=-1.5"},
+		{target: "==0"},
+		{target: "!=0"},
+		{target: "[100,200]"},
+		{target: "(100, 200)"},
+		{target: "[100,200)"},
+		{target: ">=100 && <200"},
+		{target: "<10 || >100 && <=150"},
+		{target: "", wantErr: true},
+		{target: "10", wantErr: true},
+		{target: "-10", wantErr: true},
+		{target: "=>10", wantErr: true},
+		{target: ">abc", wantErr: true},
+		{target: ">nan", wantErr: true},
+		{target: ">+Inf", wantErr: true},
+		{target: ">10 &&", wantErr: true},
+		{target: "|| <10", wantErr: true},
+		{target: "[200,100]", wantErr: true},
+		{target: "[100,200", wantErr: true},
+		{target: "[100,150,200]", wantErr: true},
+		{target: "[]", wantErr: true},
+		{target: "<=+10%"},
+		{target: "[-5%, +5%]"},
+		{target: "<=10% && <500"},
+		{target: "<=x%", wantErr: true},
+		{target: "[-5%,100]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			_, err := ParseEvaluationTarget(tt.target)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEvaluationTarget_IsFulfilledBy(t *testing.T) {
+	tests := []struct {
+		target string
+		value  float64
+		want   bool
+	}{
+		{target: ">10", value: 10, want: false},
+		{target: ">10", value: 11, want: true},
+		{target: ">=10", value: 10, want: true},
+		{target: "<10", value: 10, want: false},
+		{target: "<=10", value: 10, want: true},
+		{target: "==10", value: 10, want: true},
+		{target: "==10", value: 10.1, want: false},
+		{target: "!=10", value: 10, want: false},
+		{target: "!=10", value: 9, want: true},
+		{target: "[100,200]", value: 100, want: true},
+		{target: "[100,200]", value: 200, want: true},
+		{target: "[100,200]", value: 201, want: false},
+		{target: "(100,200)", value: 100, want: false},
+		{target: "(100,200)", value: 200, want: false},
+		{target: "(100,200)", value: 150, want: true},
+		{target: ">=100 && <200", value: 200, want: false},
+		{target: ">=100 && <200", value: 199, want: true},
+		{target: "<10 || >100", value: 50, want: false},
+		{target: "<10 || >100", value: 5, want: true},
+		{target: "<10 || >100", value: 105, want: true},
+		{target: "<10 || >100 && <=150", value: 151, want: false},
+		{target: "<10 || >100 && <=150", value: 150, want: true},
+		{target: "!=10", value: math.NaN(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			target, err := ParseEvaluationTarget(tt.target)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, target.IsFulfilledBy(tt.value))
+		})
+	}
+}
+
+func TestEvaluationTarget_ResolveBaseline(t *testing.T) {
+	tests := []struct {
+		target   string
+		baseline float64
+		value    float64
+		want     bool
+	}{
+		{target: "<=+10%", baseline: 100, value: 110, want: true},
+		{target: "<=+10%", baseline: 100, value: 110.1, want: false},
+		{target: ">=-5%", baseline: 200, value: 190, want: true},
+		{target: ">=-5%", baseline: 200, value: 189, want: false},
+		{target: "[-5%,+5%]", baseline: 100, value: 104, want: true},
+		{target: "[-5%,+5%]", baseline: 100, value: 94, want: false},
+		{target: "<=+10% && <500", baseline: 1000, value: 600, want: false},
+		{target: "<=+10%", baseline: -100, value: -90, want: true},
+		{target: ">10", baseline: 100, value: 11, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			target, err := ParseEvaluationTarget(tt.target)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, target.ResolveBaseline(tt.baseline).IsFulfilledBy(tt.value))
+		})
+	}
+}
+
+func TestEvaluationTarget_IsRelative(t *testing.T) {
+	target, err := ParseEvaluationTarget("<=+10% || <5")
+	require.NoError(t, err)
+	require.True(t, target.IsRelative())
+	require.False(t, target.IsFulfilledBy(1000))
+	require.False(t, target.ResolveBaseline(1).IsRelative())
+
+	target, err = ParseEvaluationTarget("[5,10]")
+	require.NoError(t, err)
+	require.False(t, target.IsRelative())
+}
+
+func TestEvaluationTarget_DropRelative(t *testing.T) {
+	target, err := ParseEvaluationTarget("<=+10% && <500")
+	require.NoError(t, err)
+	require.True(t, target.DropRelative().IsFulfilledBy(499))
+	require.False(t, target.DropRelative().IsFulfilledBy(500))
+
+	target, err = ParseEvaluationTarget("<=+10%")
+	require.NoError(t, err)
+	require.False(t, target.DropRelative().IsFulfilledBy(0))
+	require.False(t, target.DropRelative().IsFulfilledBy(1000))
+
+	target, err = ParseEvaluationTarget("<=+10% || <5")
+	require.NoError(t, err)
+	require.True(t, target.DropRelative().IsFulfilledBy(4))
+	require.False(t, target.DropRelative().IsFulfilledBy(1000))
+}
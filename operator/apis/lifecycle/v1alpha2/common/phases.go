@@ -19,11 +19,13 @@ var phases = []KeptnPhaseType{
 	PhaseWorkloadPreEvaluation,
 	PhaseWorkloadPostEvaluation,
 	PhaseWorkloadDeployment,
+	PhaseWorkloadOnFailure,
 	PhaseAppPreDeployment,
 	PhaseAppPostDeployment,
 	PhaseAppPreEvaluation,
 	PhaseAppPostEvaluation,
 	PhaseAppDeployment,
+	PhaseAppOnFailure,
 	PhaseReconcileEvaluation,
 	PhaseReconcileTask,
 	PhaseCreateEvaluation,
@@ -82,11 +84,13 @@ var (
 	PhaseWorkloadPreEvaluation  = KeptnPhaseType{LongName: "Workload Pre-Deployment Evaluations", ShortName: "WorkloadPreDeployEvaluations"}
 	PhaseWorkloadPostEvaluation = KeptnPhaseType{LongName: "Workload Post-Deployment Evaluations", ShortName: "WorkloadPostDeployEvaluations"}
 	PhaseWorkloadDeployment     = KeptnPhaseType{LongName: "Workload Deployment", ShortName: "WorkloadDeploy"}
+	PhaseWorkloadOnFailure      = KeptnPhaseType{LongName: "Workload On-Failure Tasks", ShortName: "WorkloadOnFailureTasks"}
 	PhaseAppPreDeployment       = KeptnPhaseType{LongName: "App Pre-Deployment Tasks", ShortName: "AppPreDeployTasks"}
 	PhaseAppPostDeployment      = KeptnPhaseType{LongName: "App Post-Deployment Tasks", ShortName: "AppPostDeployTasks"}
 	PhaseAppPreEvaluation       = KeptnPhaseType{LongName: "App Pre-Deployment Evaluations", ShortName: "AppPreDeployEvaluations"}
 	PhaseAppPostEvaluation      = KeptnPhaseType{LongName: "App Post-Deployment Evaluations", ShortName: "AppPostDeployEvaluations"}
 	PhaseAppDeployment          = KeptnPhaseType{LongName: "App Deployment", ShortName: "AppDeploy"}
+	PhaseAppOnFailure           = KeptnPhaseType{LongName: "App On-Failure Tasks", ShortName: "AppOnFailureTasks"}
 	PhaseReconcileEvaluation    = KeptnPhaseType{LongName: "Reconcile Evaluation", ShortName: "ReconcileEvaluation"}
 	PhaseReconcileTask          = KeptnPhaseType{LongName: "Reconcile Task", ShortName: "ReconcileTask"}
 	PhaseCreateEvaluation       = KeptnPhaseType{LongName: "Create Evaluation", ShortName: "CreateEvaluation"}
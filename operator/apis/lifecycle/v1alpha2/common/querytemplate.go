@@ -0,0 +1,66 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// QueryContext holds the deployment context that can be used in queries, e.g.
+//
+//	rate(http_requests_total{service="{{.Workload}}",version="{{.Version}}"}[5m])
+type QueryContext struct {
+	// Workload is the name of the workload, empty for app evaluations
+	Workload string
+	// Version is the version of the workload, or of the app for app evaluations
+	Version string
+	// PreviousVersion is the previous version of the workload, or of the app for app evaluations
+	PreviousVersion string
+	AppName         string
+	AppVersion      string
+	Namespace       string
+	// Outputs holds the outputs of the succeeded tasks of the same application or workload version
+	// by the name of their task definition, e.g. {{index .Outputs "create-ticket" "ticketId"}}
+	Outputs map[string]map[string]string
+}
+
+// MetricQueryContext holds the context that can be used in the queries of KeptnMetrics,
+// which are not bound to a deployment
+type MetricQueryContext struct {
+	Namespace string
+}
+
+// ResolveQuery fills the placeholders of the given query with the values of the QueryContext or MetricQueryContext.
+// Queries without placeholders are returned unchanged, placeholders the context has no field for are an error.
+func ResolveQuery(query string, queryContext interface{}) (string, error) {
+	return resolveQuery(query, queryContext, "error")
+}
+
+func resolveQuery(query string, queryContext interface{}, missingKey string) (string, error) {
+	if !strings.Contains(query, "{{") {
+		return query, nil
+	}
+	tmpl, err := template.New("query").Option("missingkey=" + missingKey).Parse(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid query template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, queryContext); err != nil {
+		return "", fmt.Errorf("could not resolve query template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// ValidateQuery checks that the given query is a valid template which only uses fields of QueryContext,
+// task outputs are only known once the tasks have run, so that any output is accepted
+func ValidateQuery(query string) error {
+	_, err := resolveQuery(query, QueryContext{}, "zero")
+	return err
+}
+
+// ValidateMetricQuery checks that the given query is a valid template which only uses fields of MetricQueryContext
+func ValidateMetricQuery(query string) error {
+	_, err := ResolveQuery(query, MetricQueryContext{})
+	return err
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveQuery(t *testing.T) {
+	queryContext := QueryContext{
+		Workload:        "podtato-head-entry",
+		Version:         "0.2.7",
+		PreviousVersion: "0.2.6",
+		AppName:         "podtato-head",
+		AppVersion:      "1.3",
+		Namespace:       "podtato-kubectl",
+	}
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "no placeholders",
+			query: `sum(kube_pod_container_resource_limits{resource="cpu"})`,
+			want:  `sum(kube_pod_container_resource_limits{resource="cpu"})`,
+		},
+		{
+			name:  "all placeholders",
+			query: `{{.Workload}}|{{.Version}}|{{.PreviousVersion}}|{{.AppName}}|{{.AppVersion}}|{{.Namespace}}`,
+			want:  `podtato-head-entry|0.2.7|0.2.6|podtato-head|1.3|podtato-kubectl`,
+		},
+		{
+			name:  "prometheus query",
+			query: `rate(http_requests_total{service="{{.Workload}}",namespace="{{.Namespace}}"}[5m])`,
+			want:  `rate(http_requests_total{service="podtato-head-entry",namespace="podtato-kubectl"}[5m])`,
+		},
+		{
+			name:    "unknown placeholder",
+			query:   `{{.Service}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			query:   `{{.Workload`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveQuery(tt.query, queryContext)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Error(t, ValidateQuery(tt.query))
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, ValidateQuery(tt.query))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveQueryTaskOutputs(t *testing.T) {
+	queryContext := QueryContext{
+		Outputs: map[string]map[string]string{
+			"canary":        {"weight": "10"},
+			"create-ticket": {"ticketId": "CHG-42"},
+		},
+	}
+
+	got, err := ResolveQuery(`{{.Outputs.canary.weight}}|{{index .Outputs "create-ticket" "ticketId"}}`, queryContext)
+	require.NoError(t, err)
+	require.Equal(t, `10|CHG-42`, got)
+
+	// the outputs are only known once the tasks have run, a missing output fails the query but not its validation
+	_, err = ResolveQuery(`{{.Outputs.rollback.weight}}`, queryContext)
+	require.Error(t, err)
+	require.NoError(t, ValidateQuery(`{{.Outputs.rollback.weight}}`))
+	require.Error(t, ValidateMetricQuery(`{{.Outputs.canary.weight}}`))
+}
+
+func TestResolveMetricQuery(t *testing.T) {
+	got, err := ResolveQuery(`up{namespace="{{.Namespace}}"}`, MetricQueryContext{Namespace: "podtato-kubectl"})
+	require.NoError(t, err)
+	require.Equal(t, `up{namespace="podtato-kubectl"}`, got)
+	require.NoError(t, ValidateMetricQuery(`up{namespace="{{.Namespace}}"}`))
+
+	// metrics are not bound to a deployment, so there is nothing to fill the deployment placeholders with
+	for _, query := range []string{`{{.Workload}}`, `{{.Version}}`, `{{.PreviousVersion}}`, `{{.AppName}}`, `{{.AppVersion}}`} {
+		_, err := ResolveQuery(query, MetricQueryContext{Namespace: "podtato-kubectl"})
+		require.Error(t, err)
+		require.Error(t, ValidateMetricQuery(query))
+	}
+}
@@ -51,3 +51,73 @@ func TestKeptnApp(t *testing.T) {
 		"appRevision": "1",
 	}, app.GetEventAnnotations())
 }
+
+func TestTaskExecution_GetDependencies(t *testing.T) {
+	tasks := []string{"migrate-db", "warm-cache", "notify"}
+	tests := []struct {
+		name      string
+		execution *TaskExecution
+		task      string
+		want      []string
+	}{
+		{
+			name: "no execution",
+			task: "notify",
+		},
+		{
+			name:      "parallel",
+			execution: &TaskExecution{Mode: TaskExecutionParallel},
+			task:      "notify",
+		},
+		{
+			name:      "sequential",
+			execution: &TaskExecution{Mode: TaskExecutionSequential},
+			task:      "notify",
+			want:      []string{"migrate-db", "warm-cache"},
+		},
+		{
+			name:      "sequential first task",
+			execution: &TaskExecution{Mode: TaskExecutionSequential},
+			task:      "migrate-db",
+			want:      []string{},
+		},
+		{
+			name: "dag",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"warm-cache": {"migrate-db", "other-phase"}},
+			},
+			task: "warm-cache",
+			want: []string{"migrate-db"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.execution.GetDependencies(tt.task, tasks))
+		})
+	}
+}
+
+func TestTaskExecution_ValidateDependencies(t *testing.T) {
+	tasks := []string{"a", "b", "c"}
+	var execution *TaskExecution
+	require.Nil(t, execution.ValidateDependencies(tasks))
+
+	execution = &TaskExecution{
+		Mode:      TaskExecutionDAG,
+		DependsOn: map[string][]string{"b": {"a"}, "c": {"a", "b"}},
+	}
+	require.Nil(t, execution.ValidateDependencies(tasks))
+
+	execution.DependsOn["a"] = []string{"c"}
+	require.EqualError(t, execution.ValidateDependencies(tasks), "tasks depend on each other in a cycle: a -> c -> a")
+
+	execution.DependsOn = map[string][]string{"a": {"a"}}
+	require.EqualError(t, execution.ValidateDependencies(tasks), "tasks depend on each other in a cycle: a -> a")
+
+	execution.DependsOn = map[string][]string{"a": {"b"}}
+	require.EqualError(t, execution.ValidateDependencies([]string{"a", "c"}), "task a depends on unknown task b")
+
+	execution.DependsOn = map[string][]string{"b": {"a"}}
+	require.EqualError(t, execution.ValidateDependencies([]string{"a", "c"}), "dependencies of unknown task b")
+}
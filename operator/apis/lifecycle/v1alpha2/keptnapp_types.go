@@ -18,6 +18,7 @@ package v1alpha2
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -40,6 +41,12 @@ type KeptnAppSpec struct {
 	PostDeploymentTasks       []string           `json:"postDeploymentTasks,omitempty"`
 	PreDeploymentEvaluations  []string           `json:"preDeploymentEvaluations,omitempty"`
 	PostDeploymentEvaluations []string           `json:"postDeploymentEvaluations,omitempty"`
+	// TaskExecution defines the order in which the pre- and post-deployment tasks run, by default they run in parallel
+	// +optional
+	TaskExecution *TaskExecution `json:"taskExecution,omitempty"`
+	// OnFailureTasks are run once any phase of the deployment has failed, e.g. to notify, open an incident or roll back
+	// +optional
+	OnFailureTasks []string `json:"onFailureTasks,omitempty"`
 }
 
 // KeptnAppStatus defines the observed state of KeptnApp
@@ -52,6 +59,121 @@ type KeptnWorkloadRef struct {
 	Version string `json:"version"`
 }
 
+type TaskExecutionMode string
+
+const (
+	TaskExecutionParallel   TaskExecutionMode = "parallel"
+	TaskExecutionSequential TaskExecutionMode = "sequential"
+	TaskExecutionDAG        TaskExecutionMode = "dag"
+)
+
+// TaskExecution defines the order in which the tasks of a phase run
+type TaskExecution struct {
+	// Mode is parallel, sequential to run the tasks in the order in which they are listed,
+	// or dag to run each task once the tasks it depends on have succeeded
+	// +kubebuilder:validation:Enum:=parallel;sequential;dag
+	// +kubebuilder:default:=parallel
+	// +optional
+	Mode TaskExecutionMode `json:"mode,omitempty"`
+	// DependsOn maps the name of a task definition to the task definitions that must succeed before it, used by the
+	// dag mode. All names must be pre-deployment, post-deployment or on-failure tasks. A task only waits for the
+	// tasks of its own phase, since the tasks of earlier phases have finished before it.
+	// +optional
+	DependsOn map[string][]string `json:"dependsOn,omitempty"`
+}
+
+// GetDependencies returns the tasks of the phase that must succeed before the task can start
+func (e *TaskExecution) GetDependencies(task string, tasks []string) []string {
+	if e == nil {
+		return nil
+	}
+	switch e.Mode {
+	case TaskExecutionSequential:
+		for i, t := range tasks {
+			if t == task {
+				return tasks[:i]
+			}
+		}
+	case TaskExecutionDAG:
+		var dependencies []string
+		for _, dependency := range e.DependsOn[task] {
+			if containsString(tasks, dependency) {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		return dependencies
+	}
+	return nil
+}
+
+// ValidateDependencies returns an error if the dependencies name tasks that are not in the given list of all
+// pre-deployment, post-deployment and on-failure tasks, or if tasks depend on each other in a cycle
+func (e *TaskExecution) ValidateDependencies(tasks []string) error {
+	if e == nil || e.Mode != TaskExecutionDAG {
+		return nil
+	}
+	names := make([]string, 0, len(e.DependsOn))
+	for task := range e.DependsOn {
+		names = append(names, task)
+	}
+	sort.Strings(names)
+	for _, task := range names {
+		if !containsString(tasks, task) {
+			return fmt.Errorf("dependencies of unknown task %s", task)
+		}
+		for _, dependency := range e.DependsOn[task] {
+			if !containsString(tasks, dependency) {
+				return fmt.Errorf("task %s depends on unknown task %s", task, dependency)
+			}
+		}
+	}
+	// tasks are visited depth first, a task that is reached again while its dependencies are visited is in a cycle
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(tasks))
+	var path []string
+	var visit func(task string) error
+	visit = func(task string) error {
+		switch states[task] {
+		case visiting:
+			for i, t := range path {
+				if t == task {
+					return fmt.Errorf("tasks depend on each other in a cycle: %s", strings.Join(append(path[i:], task), " -> "))
+				}
+			}
+		case visited:
+			return nil
+		}
+		states[task] = visiting
+		path = append(path, task)
+		for _, dependency := range e.DependsOn[task] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[task] = visited
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:storageversion
 //+kubebuilder:subresource:status
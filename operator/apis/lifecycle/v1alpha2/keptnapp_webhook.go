@@ -1,11 +1,58 @@
 package v1alpha2
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// log is for logging in this package.
+var keptnapplog = logf.Log.WithName("keptnapp-resource")
+
 func (r *KeptnApp) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1alpha2-keptnapp,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnapps,verbs=create;update,versions=v1alpha2,name=vkeptnapp.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnApp{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnApp) ValidateCreate() error {
+	keptnapplog.Info("validate create", "name", r.Name)
+	return r.validateKeptnApp()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnApp) ValidateUpdate(old runtime.Object) error {
+	keptnapplog.Info("validate update", "name", r.Name)
+	return r.validateKeptnApp()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnApp) ValidateDelete() error {
+	keptnapplog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *KeptnApp) validateKeptnApp() error {
+	var tasks []string
+	tasks = append(tasks, r.Spec.PreDeploymentTasks...)
+	tasks = append(tasks, r.Spec.PostDeploymentTasks...)
+	tasks = append(tasks, r.Spec.OnFailureTasks...)
+	if err := r.Spec.TaskExecution.ValidateDependencies(tasks); err != nil {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnApp"},
+			r.Name,
+			field.ErrorList{field.Invalid(field.NewPath("spec").Child("taskExecution").Child("dependsOn"), r.Spec.TaskExecution.DependsOn, err.Error())},
+		)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeptnApp_validateKeptnApp(t *testing.T) {
+	tests := []struct {
+		name      string
+		execution *TaskExecution
+		wantErr   string
+	}{
+		{
+			name: "no-task-execution",
+		},
+		{
+			name:      "sequential",
+			execution: &TaskExecution{Mode: TaskExecutionSequential, DependsOn: map[string][]string{"unknown": {"a"}}},
+		},
+		{
+			name: "valid-dependencies",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"b": {"a"}, "c": {"a", "b"}, "rollback": {"c"}},
+			},
+		},
+		{
+			name: "unknown-task",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"d": {"a"}},
+			},
+			wantErr: "dependencies of unknown task d",
+		},
+		{
+			name: "unknown-dependency",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"b": {"a", "migrate"}},
+			},
+			wantErr: "task b depends on unknown task migrate",
+		},
+		{
+			name: "self-dependency",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"a": {"a"}},
+			},
+			wantErr: "tasks depend on each other in a cycle: a -> a",
+		},
+		{
+			name: "cycle",
+			execution: &TaskExecution{
+				Mode:      TaskExecutionDAG,
+				DependsOn: map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}},
+			},
+			wantErr: "tasks depend on each other in a cycle: a -> c -> b -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &KeptnApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: KeptnAppSpec{
+					Version:             "1.0.0",
+					PreDeploymentTasks:  []string{"a", "b"},
+					PostDeploymentTasks: []string{"c"},
+					OnFailureTasks:      []string{"rollback"},
+					TaskExecution:       tt.execution,
+				},
+			}
+			err := app.ValidateCreate()
+			if tt.wantErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "spec.taskExecution.dependsOn")
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
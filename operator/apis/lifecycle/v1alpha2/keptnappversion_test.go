@@ -192,6 +192,7 @@ func TestKeptnAppVersion(t *testing.T) {
 	require.Equal(t, KeptnEvaluationSpec{
 		AppVersion:           app.GetVersion(),
 		AppName:              app.GetParentName(),
+		PreviousVersion:      "prev",
 		EvaluationDefinition: "taskdef",
 		Type:                 common.PostDeploymentCheckType,
 		RetryInterval: metav1.Duration{
@@ -446,3 +447,36 @@ func TestKeptnAppVersionList(t *testing.T) {
 	got = list.GetItems()
 	require.Len(t, got, 1)
 }
+
+func TestKeptnAppVersion_OnFailure(t *testing.T) {
+	app := &KeptnAppVersion{
+		Spec: KeptnAppVersionSpec{
+			KeptnAppSpec: KeptnAppSpec{
+				OnFailureTasks: []string{"rollback"},
+			},
+		},
+		Status: KeptnAppVersionStatus{
+			OnFailureStatus: common.StateProgressing,
+			OnFailureTaskStatus: []ItemStatus{
+				{
+					DefinitionName: "rollback",
+					Status:         common.StateProgressing,
+					Name:           "on-failure-rollback-12345",
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []string{"rollback"}, app.GetOnFailureTasks())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "rollback",
+			Status:         common.StateProgressing,
+			Name:           "on-failure-rollback-12345",
+		},
+	}, app.GetOnFailureTaskStatus())
+	require.False(t, app.IsOnFailureCompleted())
+
+	app.Status.OnFailureStatus = common.StateFailed
+	require.True(t, app.IsOnFailureCompleted())
+}
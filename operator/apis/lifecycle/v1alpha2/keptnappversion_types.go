@@ -59,6 +59,10 @@ type KeptnAppVersionStatus struct {
 	PreDeploymentEvaluationTaskStatus  []ItemStatus        `json:"preDeploymentEvaluationTaskStatus,omitempty"`
 	PostDeploymentEvaluationTaskStatus []ItemStatus        `json:"postDeploymentEvaluationTaskStatus,omitempty"`
 	PhaseTraceIDs                      common.PhaseTraceID `json:"phaseTraceIDs,omitempty"`
+	// OnFailureStatus is the state of the on-failure tasks, which run once a phase has failed
+	// +kubebuilder:default:=Pending
+	OnFailureStatus     common.KeptnState `json:"onFailureStatus,omitempty"`
+	OnFailureTaskStatus []ItemStatus      `json:"onFailureTaskStatus,omitempty"`
 	// +kubebuilder:default:=Pending
 	Status common.KeptnState `json:"status,omitempty"`
 
@@ -169,6 +173,10 @@ func (a KeptnAppVersion) IsPostDeploymentEvaluationFailed() bool {
 	return a.Status.PostDeploymentEvaluationStatus.IsFailed()
 }
 
+func (a KeptnAppVersion) IsOnFailureCompleted() bool {
+	return a.Status.OnFailureStatus.IsCompleted()
+}
+
 func (a KeptnAppVersion) IsPostDeploymentSucceeded() bool {
 	return a.Status.PostDeploymentStatus.IsSucceeded()
 }
@@ -254,6 +262,10 @@ func (a KeptnAppVersion) GetPostDeploymentTasks() []string {
 	return a.Spec.PostDeploymentTasks
 }
 
+func (a KeptnAppVersion) GetTaskExecution() *TaskExecution {
+	return a.Spec.TaskExecution
+}
+
 func (a KeptnAppVersion) GetPreDeploymentTaskStatus() []ItemStatus {
 	return a.Status.PreDeploymentTaskStatus
 }
@@ -262,6 +274,14 @@ func (a KeptnAppVersion) GetPostDeploymentTaskStatus() []ItemStatus {
 	return a.Status.PostDeploymentTaskStatus
 }
 
+func (a KeptnAppVersion) GetOnFailureTasks() []string {
+	return a.Spec.OnFailureTasks
+}
+
+func (a KeptnAppVersion) GetOnFailureTaskStatus() []ItemStatus {
+	return a.Status.OnFailureTaskStatus
+}
+
 func (a KeptnAppVersion) GetPreDeploymentEvaluations() []string {
 	return a.Spec.PreDeploymentEvaluations
 }
@@ -336,6 +356,7 @@ func (a KeptnAppVersion) GenerateEvaluation(evaluationDefinition string, checkTy
 		Spec: KeptnEvaluationSpec{
 			AppVersion:           a.Spec.Version,
 			AppName:              a.Spec.AppName,
+			PreviousVersion:      a.GetPreviousVersion(),
 			EvaluationDefinition: evaluationDefinition,
 			Type:                 checkType,
 			RetryInterval: metav1.Duration{
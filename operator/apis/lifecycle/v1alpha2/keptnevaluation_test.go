@@ -115,3 +115,31 @@ func TestKeptnEvaluationList(t *testing.T) {
 	got := list.GetItems()
 	require.Len(t, got, 2)
 }
+
+func TestKeptnEvaluation_GetQueryContext(t *testing.T) {
+	evaluation := KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "evaluation",
+			Namespace: "namespace",
+		},
+		Spec: KeptnEvaluationSpec{
+			Workload:        "workload",
+			WorkloadVersion: "2.0",
+			PreviousVersion: "1.0",
+			AppName:         "app",
+			AppVersion:      "appversion",
+		},
+	}
+	require.Equal(t, common.QueryContext{
+		Workload:        "workload",
+		Version:         "2.0",
+		PreviousVersion: "1.0",
+		AppName:         "app",
+		AppVersion:      "appversion",
+		Namespace:       "namespace",
+	}, evaluation.GetQueryContext())
+
+	evaluation.Spec.Workload = ""
+	evaluation.Spec.WorkloadVersion = ""
+	require.Equal(t, "appversion", evaluation.GetQueryContext().Version)
+}
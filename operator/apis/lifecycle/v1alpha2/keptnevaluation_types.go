@@ -32,10 +32,13 @@ import (
 
 // KeptnEvaluationSpec defines the desired state of KeptnEvaluation
 type KeptnEvaluationSpec struct {
-	Workload             string `json:"workload,omitempty"`
-	WorkloadVersion      string `json:"workloadVersion"`
-	AppName              string `json:"appName,omitempty"`
-	AppVersion           string `json:"appVersion,omitempty"`
+	Workload        string `json:"workload,omitempty"`
+	WorkloadVersion string `json:"workloadVersion"`
+	AppName         string `json:"appName,omitempty"`
+	AppVersion      string `json:"appVersion,omitempty"`
+	// PreviousVersion is the previous version of the workload, or of the app for app evaluations
+	// +optional
+	PreviousVersion      string `json:"previousVersion,omitempty"`
 	EvaluationDefinition string `json:"evaluationDefinition"`
 	// +kubebuilder:default:=10
 	Retries int `json:"retries,omitempty"`
@@ -44,11 +47,62 @@ type KeptnEvaluationSpec struct {
 	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	// +kubebuilder:validation:Type:=string
 	// +optional
-	RetryInterval metav1.Duration  `json:"retryInterval,omitempty"`
-	FailAction    string           `json:"failAction,omitempty"`
-	Type          common.CheckType `json:"checkType,omitempty"`
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+	// Backoff increases the interval between retries exponentially, starting with RetryInterval.
+	// If not set, the evaluation is retried at a fixed RetryInterval.
+	// +optional
+	Backoff *EvaluationBackoff `json:"backoff,omitempty"`
+	// InitialDelay is the time to wait before the first query, e.g. to let a new version warm up
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	InitialDelay metav1.Duration `json:"initialDelay,omitempty"`
+	// Timeout is the total time the evaluation may take, starting with its first reconciliation.
+	// If set, it is used instead of Retries, so that the evaluation is retried until it passes or the timeout is reached.
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// FailAction defines what happens when the evaluation does not pass. With fail, retries stop as soon as an
+	// objective is violated. With warn, the evaluation succeeds with a warning once all retries are used up.
+	// With ignore, the evaluation fails, but the failure does not affect the phase of the workload or app.
+	// If not set, the fail action of the evaluation definition is used, and if that is not set either,
+	// the evaluation is retried and fails once all retries are used up.
+	// +kubebuilder:validation:Enum:=fail;warn;ignore
+	// +optional
+	FailAction string           `json:"failAction,omitempty"`
+	Type       common.CheckType `json:"checkType,omitempty"`
 }
 
+type EvaluationBackoff struct {
+	// Factor is the factor by which the retry interval grows with every retry
+	// +kubebuilder:default:=2
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Factor int `json:"factor,omitempty"`
+	// MaxInterval caps the retry interval
+	// +kubebuilder:default:="5m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	MaxInterval metav1.Duration `json:"maxInterval,omitempty"`
+	// JitterPercent randomizes each retry interval by up to the given percentage in both directions,
+	// so that evaluations that started together do not query the provider at the same time
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	JitterPercent int `json:"jitterPercent,omitempty"`
+}
+
+const (
+	// FailActionFail stops retrying an evaluation as soon as an objective is violated
+	FailActionFail = "fail"
+	// FailActionWarn turns a failed evaluation into a succeeded evaluation with a warning
+	FailActionWarn = "warn"
+	// FailActionIgnore keeps the failed evaluation, but does not fail the phase
+	FailActionIgnore = "ignore"
+)
+
 // KeptnEvaluationStatus defines the observed state of KeptnEvaluation
 type KeptnEvaluationStatus struct {
 	// +kubebuilder:default:=0
@@ -56,14 +110,56 @@ type KeptnEvaluationStatus struct {
 	EvaluationStatus map[string]EvaluationStatusItem `json:"evaluationStatus"`
 	// +kubebuilder:default:=Pending
 	OverallStatus common.KeptnState `json:"overallStatus"`
-	StartTime     metav1.Time       `json:"startTime,omitempty"`
-	EndTime       metav1.Time       `json:"endTime,omitempty"`
+	// Score is the total score of the last evaluation attempt, in percent of the maximum score
+	// +optional
+	Score string `json:"score,omitempty"`
+	// Warning is set when the last evaluation attempt did not reach the pass percentage, but the warning percentage
+	// +optional
+	Warning bool `json:"warning,omitempty"`
+	// History holds a compact summary of the last evaluation attempts, oldest first
+	// +optional
+	History []EvaluationAttempt `json:"history,omitempty"`
+	// FailAction is the fail action that applies to the evaluation, from its spec or its definition
+	// +optional
+	FailAction string      `json:"failAction,omitempty"`
+	StartTime  metav1.Time `json:"startTime,omitempty"`
+	EndTime    metav1.Time `json:"endTime,omitempty"`
 }
 
 type EvaluationStatusItem struct {
 	Value   string            `json:"value"`
 	Status  common.KeptnState `json:"status"`
 	Message string            `json:"message,omitempty"`
+	// Baseline is the value computed from previous evaluations that relative targets were compared with
+	// +optional
+	Baseline string `json:"baseline,omitempty"`
+	// Query is the query that was sent to the provider, after the placeholders were resolved
+	// +optional
+	Query string `json:"query,omitempty"`
+	// RawValue is the response of the provider, truncated to 4KiB
+	// +optional
+	RawValue []byte `json:"rawValue,omitempty"`
+	// QueryTime is the time the query was sent to the provider
+	// +optional
+	QueryTime metav1.Time `json:"queryTime,omitempty"`
+	// Attempt is the evaluation attempt that produced the result, starting at 1
+	// +optional
+	Attempt int `json:"attempt,omitempty"`
+}
+
+type EvaluationAttempt struct {
+	Attempt int         `json:"attempt"`
+	Time    metav1.Time `json:"time"`
+	Score   string      `json:"score,omitempty"`
+	// Objectives holds the value and the status of each objective in this attempt
+	// +optional
+	Objectives []ObjectiveResult `json:"objectives,omitempty"`
+}
+
+type ObjectiveResult struct {
+	Name   string            `json:"name"`
+	Value  string            `json:"value,omitempty"`
+	Status common.KeptnState `json:"status"`
 }
 
 //+kubebuilder:object:root=true
@@ -77,6 +173,7 @@ type EvaluationStatusItem struct {
 //+kubebuilder:printcolumn:name="RetryCount",type=string,JSONPath=`.status.retryCount`
 //+kubebuilder:printcolumn:name="EvaluationStatus",type=string,JSONPath=`.status.evaluationStatus`
 //+kubebuilder:printcolumn:name="OverallStatus",type=string,JSONPath=`.status.overallStatus`
+//+kubebuilder:printcolumn:name="Score",type=string,JSONPath=`.status.score`
 
 // KeptnEvaluation is the Schema for the keptnevaluations API
 type KeptnEvaluation struct {
@@ -108,6 +205,22 @@ func (e KeptnEvaluationList) GetItems() []client.Object {
 	return b
 }
 
+// GetFactor returns the factor of the backoff, which defaults to 2
+func (b EvaluationBackoff) GetFactor() int {
+	if b.Factor < 1 {
+		return 2
+	}
+	return b.Factor
+}
+
+// GetMaxInterval returns the maximum retry interval of the backoff, which defaults to 5m
+func (b EvaluationBackoff) GetMaxInterval() time.Duration {
+	if b.MaxInterval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return b.MaxInterval.Duration
+}
+
 func (e *KeptnEvaluation) SetStartTime() {
 	if e.Status.StartTime.IsZero() {
 		e.Status.StartTime = metav1.NewTime(time.Now().UTC())
@@ -163,6 +276,30 @@ func (e *KeptnEvaluation) AddEvaluationStatus(objective Objective) {
 
 }
 
+// GetFailAction returns the fail action of the evaluation, the evaluation overrides its definition
+func (e KeptnEvaluation) GetFailAction(definition KeptnEvaluationDefinitionSpec) string {
+	if e.Spec.FailAction != "" {
+		return e.Spec.FailAction
+	}
+	return definition.FailAction
+}
+
+// GetQueryContext returns the deployment context that is used to resolve the queries of the evaluation's objectives
+func (e KeptnEvaluation) GetQueryContext() common.QueryContext {
+	version := e.Spec.WorkloadVersion
+	if e.Spec.Workload == "" {
+		version = e.Spec.AppVersion
+	}
+	return common.QueryContext{
+		Workload:        e.Spec.Workload,
+		Version:         version,
+		PreviousVersion: e.Spec.PreviousVersion,
+		AppName:         e.Spec.AppName,
+		AppVersion:      e.Spec.AppVersion,
+		Namespace:       e.Namespace,
+	}
+}
+
 func (e KeptnEvaluation) SetSpanAttributes(span trace.Span) {
 	span.SetAttributes(e.GetSpanAttributes()...)
 }
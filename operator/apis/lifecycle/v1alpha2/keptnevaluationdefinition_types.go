@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,12 +29,95 @@ import (
 type KeptnEvaluationDefinitionSpec struct {
 	Source     string      `json:"source"`
 	Objectives []Objective `json:"objectives"`
+	// TotalScore defines which percentage of the maximum score must be reached for the evaluation to pass or to
+	// result in a warning. If not set, all objectives must pass.
+	// +optional
+	TotalScore TotalScore `json:"totalScore,omitempty"`
+	// MaxConcurrency is the maximum number of objectives of an evaluation that are queried at the same time
+	// +kubebuilder:default:=5
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// FailAction is used for the evaluations that workloads and apps create from this definition,
+	// see KeptnEvaluationSpec.FailAction
+	// +kubebuilder:validation:Enum:=fail;warn;ignore
+	// +optional
+	FailAction string `json:"failAction,omitempty"`
 }
 
 type Objective struct {
-	Name             string `json:"name"`
-	Query            string `json:"query"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// EvaluationTarget is the pass criteria of the objective
 	EvaluationTarget string `json:"evaluationTarget"`
+	// WarningTarget is the warning criteria of the objective. An objective that does not meet its EvaluationTarget,
+	// but meets its WarningTarget, contributes half of its weight to the total score.
+	// +optional
+	WarningTarget string `json:"warningTarget,omitempty"`
+	// Weight is the number of points an objective contributes to the total score when it passes
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Weight int `json:"weight,omitempty"`
+	// KeySLI marks an objective that must not fail, regardless of the total score
+	// +optional
+	KeySLI bool `json:"keySLI,omitempty"`
+	// Range turns the query into a range query over a time window, the resulting data points are reduced to a
+	// single value with the given aggregation. If not set, the query is evaluated at a single point in time.
+	// +optional
+	Range *QueryRange `json:"range,omitempty"`
+	// Comparison defines the baseline that relative targets, e.g. <=+10%, are compared with.
+	// If not set, relative targets are compared with the result of the last successful evaluation of a previous version.
+	// +optional
+	Comparison *ObjectiveComparison `json:"comparison,omitempty"`
+}
+
+type QueryRange struct {
+	// Interval is the length of the time window that ends at the time of the query, e.g. 5m
+	// +kubebuilder:default:="5m"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Step is the resolution of the data points within the time window
+	// +kubebuilder:default:="15s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Step metav1.Duration `json:"step,omitempty"`
+	// Aggregation is used to reduce the data points to a single value
+	// +kubebuilder:default:=avg
+	// +kubebuilder:validation:Enum:=avg;sum;min;max;count;last;p50;p90;p95;p99
+	// +optional
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+type ObjectiveComparison struct {
+	// NumberOfResults is the number of successful evaluations of previous versions the baseline is computed from
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	NumberOfResults int `json:"numberOfResults,omitempty"`
+	// AggregateFunction is used to reduce the results of previous evaluations to a single baseline
+	// +kubebuilder:default:=avg
+	// +kubebuilder:validation:Enum:=avg;median;min;max
+	// +optional
+	AggregateFunction string `json:"aggregateFunction,omitempty"`
+}
+
+type TotalScore struct {
+	// PassPercentage is the minimum percentage of the maximum score required for the evaluation to pass
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	PassPercentage int `json:"passPercentage,omitempty"`
+	// WarningPercentage is the minimum percentage of the maximum score required for the evaluation to result in a
+	// warning. A value of 0 disables warnings.
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	WarningPercentage int `json:"warningPercentage,omitempty"`
 }
 
 // KeptnEvaluationDefinitionStatus defines the observed state of KeptnEvaluationDefinition
@@ -67,3 +152,60 @@ type KeptnEvaluationDefinitionList struct {
 func init() {
 	SchemeBuilder.Register(&KeptnEvaluationDefinition{}, &KeptnEvaluationDefinitionList{})
 }
+
+func (o Objective) GetWeight() int {
+	if o.Weight < 1 {
+		return 1
+	}
+	return o.Weight
+}
+
+// GetMaxConcurrency returns the maximum number of objectives that are queried at the same time, which defaults to 5
+func (s KeptnEvaluationDefinitionSpec) GetMaxConcurrency() int {
+	if s.MaxConcurrency < 1 {
+		return 5
+	}
+	return s.MaxConcurrency
+}
+
+func (t TotalScore) GetPassPercentage() int {
+	if t.PassPercentage == 0 {
+		return 100
+	}
+	return t.PassPercentage
+}
+
+func (o Objective) GetComparison() ObjectiveComparison {
+	comparison := ObjectiveComparison{NumberOfResults: 1, AggregateFunction: "avg"}
+	if o.Comparison == nil {
+		return comparison
+	}
+	if o.Comparison.NumberOfResults > 0 {
+		comparison.NumberOfResults = o.Comparison.NumberOfResults
+	}
+	if o.Comparison.AggregateFunction != "" {
+		comparison.AggregateFunction = o.Comparison.AggregateFunction
+	}
+	return comparison
+}
+
+func (r QueryRange) GetInterval() time.Duration {
+	if r.Interval.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return r.Interval.Duration
+}
+
+func (r QueryRange) GetStep() time.Duration {
+	if r.Step.Duration <= 0 {
+		return 15 * time.Second
+	}
+	return r.Step.Duration
+}
+
+func (r QueryRange) GetAggregation() string {
+	if r.Aggregation == "" {
+		return "avg"
+	}
+	return r.Aggregation
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var keptnevaluationdefinitionlog = logf.Log.WithName("keptnevaluationdefinition-resource")
+
+func (r *KeptnEvaluationDefinition) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1alpha2-keptnevaluationdefinition,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptnevaluationdefinitions,verbs=create;update,versions=v1alpha2,name=vkeptnevaluationdefinition.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnEvaluationDefinition{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnEvaluationDefinition) ValidateCreate() error {
+	keptnevaluationdefinitionlog.Info("validate create", "name", r.Name)
+	return r.validateKeptnEvaluationDefinition()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnEvaluationDefinition) ValidateUpdate(old runtime.Object) error {
+	keptnevaluationdefinitionlog.Info("validate update", "name", r.Name)
+	return r.validateKeptnEvaluationDefinition()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnEvaluationDefinition) ValidateDelete() error {
+	keptnevaluationdefinitionlog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *KeptnEvaluationDefinition) validateKeptnEvaluationDefinition() error {
+	var allErrs field.ErrorList //defined as a list to allow returning multiple validation errors
+	allErrs = append(allErrs, r.validateObjectives()...)
+	if err := r.validateTotalScore(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnEvaluationDefinition"},
+		r.Name, allErrs)
+}
+
+func (r *KeptnEvaluationDefinition) validateObjectives() field.ErrorList {
+	var allErrs field.ErrorList
+	objectivesPath := field.NewPath("spec").Child("objectives")
+	for i, objective := range r.Spec.Objectives {
+		if err := common.ValidateQuery(objective.Query); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				objectivesPath.Index(i).Child("query"),
+				objective.Query,
+				err.Error(),
+			))
+		}
+		if _, err := common.ParseEvaluationTarget(objective.EvaluationTarget); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				objectivesPath.Index(i).Child("evaluationTarget"),
+				objective.EvaluationTarget,
+				err.Error(),
+			))
+		}
+		if objective.WarningTarget == "" {
+			continue
+		}
+		if _, err := common.ParseEvaluationTarget(objective.WarningTarget); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				objectivesPath.Index(i).Child("warningTarget"),
+				objective.WarningTarget,
+				err.Error(),
+			))
+		}
+	}
+	return allErrs
+}
+
+func (r *KeptnEvaluationDefinition) validateTotalScore() *field.Error {
+	if r.Spec.TotalScore.WarningPercentage > r.Spec.TotalScore.GetPassPercentage() {
+		return field.Invalid(
+			field.NewPath("spec").Child("totalScore").Child("warningPercentage"),
+			r.Spec.TotalScore.WarningPercentage,
+			"warningPercentage must not be greater than passPercentage",
+		)
+	}
+	return nil
+}
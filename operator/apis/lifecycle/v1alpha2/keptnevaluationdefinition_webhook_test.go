@@ -0,0 +1,86 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeptnEvaluationDefinition_validateKeptnEvaluationDefinition(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectives []Objective
+		totalScore TotalScore
+		wantErr    string
+	}{
+		{
+			name: "valid-targets",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ">10"},
+				{Name: "b", EvaluationTarget: "[100,200)"},
+				{Name: "c", EvaluationTarget: "<=1 || >=5 && !=7"},
+				{Name: "d", Query: `rate(http_requests_total{service="{{.Workload}}"}[5m])`, EvaluationTarget: ">10"},
+			},
+		},
+		{
+			name: "invalid-target",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ">10"},
+				{Name: "b", EvaluationTarget: "=>10"},
+			},
+			wantErr: "spec.objectives[1].evaluationTarget",
+		},
+		{
+			name: "invalid-warning-target",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ">10", WarningTarget: ">"},
+			},
+			wantErr: "spec.objectives[0].warningTarget",
+		},
+		{
+			name: "valid-total-score",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ">10", WarningTarget: ">5", Weight: 2},
+			},
+			totalScore: TotalScore{PassPercentage: 90, WarningPercentage: 75},
+		},
+		{
+			name: "warning-above-pass",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ">10"},
+			},
+			totalScore: TotalScore{PassPercentage: 75, WarningPercentage: 90},
+			wantErr:    "spec.totalScore.warningPercentage",
+		},
+		{
+			name: "invalid-query-template",
+			objectives: []Objective{
+				{Name: "a", Query: `rate(http_requests_total{service="{{.Service}}"}[5m])`, EvaluationTarget: ">10"},
+			},
+			wantErr: "spec.objectives[0].query",
+		},
+		{
+			name: "empty-target",
+			objectives: []Objective{
+				{Name: "a", EvaluationTarget: ""},
+			},
+			wantErr: "spec.objectives[0].evaluationTarget",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &KeptnEvaluationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
+				Spec:       KeptnEvaluationDefinitionSpec{Objectives: tt.objectives, TotalScore: tt.totalScore},
+			}
+			got := r.validateKeptnEvaluationDefinition()
+			if tt.wantErr != "" {
+				require.NotNil(t, got)
+				require.Contains(t, got.Error(), tt.wantErr)
+			} else {
+				require.Nil(t, got)
+			}
+		})
+	}
+}
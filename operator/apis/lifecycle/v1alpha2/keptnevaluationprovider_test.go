@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestHasKey(t *testing.T) {
@@ -123,3 +124,14 @@ func TestHasKey(t *testing.T) {
 
 	}
 }
+
+func TestGetType(t *testing.T) {
+	provider := KeptnEvaluationProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus"},
+	}
+	require.Equal(t, "prometheus", provider.GetType())
+
+	provider.Name = "my-json-api"
+	provider.Spec.Type = "http"
+	require.Equal(t, "http", provider.GetType())
+}
@@ -18,6 +18,7 @@ package v1alpha2
 
 import (
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,8 +29,138 @@ import (
 
 // KeptnEvaluationProviderSpec defines the desired state of KeptnEvaluationProvider
 type KeptnEvaluationProviderSpec struct {
+	// Type of the provider, e.g. prometheus or http. If not set, the name of the provider is used as its type.
+	// +kubebuilder:validation:Enum:=prometheus;dynatrace;datadog;http;plugin
+	// +optional
+	Type string `json:"type,omitempty"`
+	// TargetServer is the address of the provider. For http providers, it is a template of the URL
+	// that can contain the query, e.g. http://my-service/api/values?name={{urlquery .Query}}.
+	// For plugin providers, it is the address of the plugin, e.g. my-plugin:9090 for gRPC
+	// or http://my-plugin/evaluate for HTTP.
 	TargetServer string                   `json:"targetServer"`
 	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// Timeout of a single query
+	// +kubebuilder:default:="20s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// TLS configures the certificates used to connect to the provider
+	// +optional
+	TLS *ProviderTLSConfig `json:"tls,omitempty"`
+	// ProxyURL is the URL of the HTTP proxy the provider is reached through,
+	// if not set the proxy environment variables of the operator are used
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// BasicAuth adds basic authentication to the requests, e.g. for a Prometheus behind a reverse proxy
+	// +optional
+	BasicAuth *BasicAuthConfig `json:"basicAuth,omitempty"`
+	// RateLimit limits the number of queries that are sent to the provider by all evaluations and metrics,
+	// if not set the queries are not limited
+	// +optional
+	RateLimit *ProviderRateLimit `json:"rateLimit,omitempty"`
+	// HTTP configures the request and the extraction of the value for http providers
+	// +optional
+	HTTP *HTTPProviderConfig `json:"http,omitempty"`
+	// Plugin configures how out-of-process plugin providers are called
+	// +optional
+	Plugin *PluginProviderConfig `json:"plugin,omitempty"`
+	// Dynatrace configures the API and the authentication of dynatrace providers
+	// +optional
+	Dynatrace *DynatraceProviderConfig `json:"dynatrace,omitempty"`
+}
+
+type DynatraceProviderConfig struct {
+	// API that the queries are run with, metrics for metric selectors of the metrics API v2
+	// or dql for DQL queries on Grail
+	// +kubebuilder:default:=metrics
+	// +kubebuilder:validation:Enum:=metrics;dql
+	// +optional
+	API string `json:"api,omitempty"`
+	// OAuth authenticates with OAuth client credentials instead of an API token,
+	// the client secret is read from SecretKeyRef
+	// +optional
+	OAuth *DynatraceOAuthConfig `json:"oauth,omitempty"`
+}
+
+type DynatraceOAuthConfig struct {
+	ClientID string `json:"clientID"`
+	// TokenURL is the endpoint the access token is requested from
+	// +kubebuilder:default:="https://sso.dynatrace.com/sso/oauth2/token"
+	// +optional
+	TokenURL string `json:"tokenURL,omitempty"`
+	// Scopes of the access token, e.g. storage:metrics:read
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Resource is the URN of the environment or account, e.g. urn:dtenvironment:abc12345
+	// +optional
+	Resource string `json:"resource,omitempty"`
+}
+
+type PluginProviderConfig struct {
+	// Protocol that the plugin serves the SLIProvider contract with
+	// +kubebuilder:default:=grpc
+	// +kubebuilder:validation:Enum:=grpc;http
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type ProviderTLSConfig struct {
+	// CA references a PEM bundle of the certificate authorities that the certificate of the provider is verified with,
+	// if not set the system certificate pool is used
+	// +optional
+	CA *corev1.SecretKeySelector `json:"ca,omitempty"`
+	// Cert references the PEM encoded client certificate for mTLS
+	// +optional
+	Cert *corev1.SecretKeySelector `json:"cert,omitempty"`
+	// Key references the PEM encoded private key of the client certificate
+	// +optional
+	Key *corev1.SecretKeySelector `json:"key,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificate of the provider, do not use it in production
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+type BasicAuthConfig struct {
+	Username string `json:"username"`
+	// Password references the key of a secret that holds the password
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+type ProviderRateLimit struct {
+	// RequestsPerSecond is the number of queries per second the provider accepts on average
+	// +kubebuilder:validation:Minimum:=1
+	RequestsPerSecond int `json:"requestsPerSecond"`
+	// Burst is the number of queries that may be sent at once, it defaults to RequestsPerSecond
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
+// GetBurst returns the burst of the rate limit, which defaults to RequestsPerSecond
+func (r ProviderRateLimit) GetBurst() int {
+	if r.Burst < 1 {
+		return r.RequestsPerSecond
+	}
+	return r.Burst
+}
+
+type HTTPProviderConfig struct {
+	// Method is the HTTP method of the request
+	// +kubebuilder:default:=GET
+	// +kubebuilder:validation:Enum:=GET;POST
+	// +optional
+	Method string `json:"method,omitempty"`
+	// Headers are added to the request, the values are templates that can contain the query and the value
+	// of the referenced secret, e.g. Authorization: "Bearer {{.Secret}}"
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is a template of the request body that can contain the query, e.g. {"query": "{{.Query}}"}
+	// +optional
+	Body string `json:"body,omitempty"`
+	// JSONPath extracts the value from the JSON response, e.g. {.data.value}. The expression must match a
+	// single number, or a string that holds a number.
+	JSONPath string `json:"jsonPath"`
 }
 
 // KeptnEvaluationProviderStatus defines the observed state of KeptnEvaluationProvider
@@ -65,6 +196,22 @@ func init() {
 	SchemeBuilder.Register(&KeptnEvaluationProvider{}, &KeptnEvaluationProviderList{})
 }
 
+// GetTimeout returns the timeout of a single query, which defaults to 20s
+func (p *KeptnEvaluationProvider) GetTimeout() time.Duration {
+	if p.Spec.Timeout.Duration == 0 {
+		return 20 * time.Second
+	}
+	return p.Spec.Timeout.Duration
+}
+
+// GetType returns the type of the provider, which defaults to its name
+func (p *KeptnEvaluationProvider) GetType() string {
+	if p.Spec.Type != "" {
+		return p.Spec.Type
+	}
+	return p.Name
+}
+
 func (p *KeptnEvaluationProvider) HasSecretDefined() bool {
 	if p.Spec.SecretKeyRef == (corev1.SecretKeySelector{}) {
 		return false
@@ -74,3 +221,27 @@ func (p *KeptnEvaluationProvider) HasSecretDefined() bool {
 	}
 	return true
 }
+
+// GetProtocol returns the protocol of the plugin, which defaults to grpc
+func (p PluginProviderConfig) GetProtocol() string {
+	if p.Protocol == "" {
+		return "grpc"
+	}
+	return p.Protocol
+}
+
+// GetAPI returns the Dynatrace API the queries are run with, which defaults to metrics
+func (d DynatraceProviderConfig) GetAPI() string {
+	if d.API == "" {
+		return "metrics"
+	}
+	return d.API
+}
+
+// GetTokenURL returns the endpoint the access token is requested from
+func (o DynatraceOAuthConfig) GetTokenURL() string {
+	if o.TokenURL == "" {
+		return "https://sso.dynatrace.com/sso/oauth2/token"
+	}
+	return o.TokenURL
+}
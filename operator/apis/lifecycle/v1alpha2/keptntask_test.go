@@ -2,6 +2,7 @@ package v1alpha2
 
 import (
 	"testing"
+	"time"
 
 	"github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	"github.com/stretchr/testify/require"
@@ -127,3 +128,46 @@ func TestKeptnTaskList(t *testing.T) {
 	got := list.GetItems()
 	require.Len(t, got, 2)
 }
+
+func TestKeptnTask_RetryPolicy(t *testing.T) {
+	task := KeptnTask{}
+	definition := KeptnTaskDefinitionSpec{}
+	require.Zero(t, task.GetTimeout(definition))
+	require.Equal(t, int32(6), task.GetRetries(definition))
+	require.Equal(t, 10*time.Second, task.GetRetryInterval(definition))
+
+	retries := int32(3)
+	definition = KeptnTaskDefinitionSpec{
+		Timeout:       metav1.Duration{Duration: time.Minute},
+		Retries:       &retries,
+		RetryInterval: metav1.Duration{Duration: 30 * time.Second},
+	}
+	require.Equal(t, time.Minute, task.GetTimeout(definition))
+	require.Equal(t, int32(3), task.GetRetries(definition))
+	require.Equal(t, 30*time.Second, task.GetRetryInterval(definition))
+
+	noRetries := int32(0)
+	task.Spec = KeptnTaskSpec{
+		Timeout:       metav1.Duration{Duration: 2 * time.Minute},
+		Retries:       &noRetries,
+		RetryInterval: metav1.Duration{Duration: time.Second},
+	}
+	require.Equal(t, 2*time.Minute, task.GetTimeout(definition))
+	require.Equal(t, int32(0), task.GetRetries(definition))
+	require.Equal(t, time.Second, task.GetRetryInterval(definition))
+}
+
+func TestKeptnTask_GetFailureMessage(t *testing.T) {
+	task := KeptnTask{
+		Status: KeptnTaskStatus{
+			Message: "attempt 1 failed: job my-job failed",
+		},
+	}
+	require.Equal(t, "attempt 1 failed: job my-job failed", task.GetFailureMessage())
+
+	task.Status.FailureDetails = &TaskFailureDetails{ExitCode: 1, Reason: "Error"}
+	require.Equal(t, "attempt 1 failed: job my-job failed, exit code: 1, reason: Error", task.GetFailureMessage())
+
+	task.Status.FailureDetails.Logs = "connection refused"
+	require.Equal(t, "attempt 1 failed: job my-job failed, exit code: 1, reason: Error, logs:\nconnection refused", task.GetFailureMessage())
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
@@ -41,6 +42,20 @@ type KeptnTaskSpec struct {
 	Parameters       TaskParameters   `json:"parameters,omitempty"`
 	SecureParameters SecureParameters `json:"secureParameters,omitempty"`
 	Type             common.CheckType `json:"checkType,omitempty"`
+	// Timeout overrides the timeout of the task definition
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Retries overrides the retries of the task definition
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// RetryInterval overrides the retry interval of the task definition
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
 }
 
 type TaskContext struct {
@@ -50,6 +65,10 @@ type TaskContext struct {
 	WorkloadVersion string `json:"workloadVersion"`
 	TaskType        string `json:"taskType"`
 	ObjectType      string `json:"objectType"`
+	// Outputs holds the outputs of the tasks of the same application or workload version that have succeeded
+	// before this task was started, by the name of their task definition
+	// +optional
+	Outputs map[string]map[string]string `json:"outputs,omitempty"`
 }
 
 type TaskParameters struct {
@@ -68,10 +87,29 @@ type KeptnTaskStatus struct {
 	Message   string            `json:"message,omitempty"`
 	StartTime metav1.Time       `json:"startTime,omitempty"`
 	EndTime   metav1.Time       `json:"endTime,omitempty"`
+	// Attempt is the number of the current attempt of the task, starting with 1, every attempt runs in its own job
+	// +optional
+	Attempt int32 `json:"attempt,omitempty"`
+	// Output is the result of the task, which it writes as a JSON object of strings to the termination message
+	// of its container
+	// +optional
+	Output map[string]string `json:"output,omitempty"`
+	// FailureDetails describes why the container of the last failed attempt of the task terminated
+	// +optional
+	FailureDetails *TaskFailureDetails `json:"failureDetails,omitempty"`
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 }
 
+// TaskFailureDetails holds the exit code, the termination reason and the last lines of the logs of a failed container
+type TaskFailureDetails struct {
+	ExitCode int32  `json:"exitCode,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	// Logs are the last lines of the logs of the container
+	// +optional
+	Logs string `json:"logs,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:storageversion
 //+kubebuilder:subresource:status
@@ -112,6 +150,43 @@ func (t KeptnTaskList) GetItems() []client.Object {
 	return b
 }
 
+// GetTimeout returns the timeout of an attempt of the task, the task overrides its definition
+func (t KeptnTask) GetTimeout(definition KeptnTaskDefinitionSpec) time.Duration {
+	if t.Spec.Timeout.Duration > 0 {
+		return t.Spec.Timeout.Duration
+	}
+	return definition.GetTimeout()
+}
+
+// GetRetries returns the number of retries of the task, the task overrides its definition
+func (t KeptnTask) GetRetries(definition KeptnTaskDefinitionSpec) int32 {
+	if t.Spec.Retries != nil {
+		return *t.Spec.Retries
+	}
+	return definition.GetRetries()
+}
+
+// GetRetryInterval returns the time between two attempts of the task, the task overrides its definition
+func (t KeptnTask) GetRetryInterval(definition KeptnTaskDefinitionSpec) time.Duration {
+	if t.Spec.RetryInterval.Duration > 0 {
+		return t.Spec.RetryInterval.Duration
+	}
+	return definition.GetRetryInterval()
+}
+
+// GetFailureMessage returns the message of the task, followed by its failure details if there are any
+func (t KeptnTask) GetFailureMessage() string {
+	details := t.Status.FailureDetails
+	if details == nil {
+		return t.Status.Message
+	}
+	message := fmt.Sprintf("%s, exit code: %d, reason: %s", t.Status.Message, details.ExitCode, details.Reason)
+	if details.Logs != "" {
+		message = fmt.Sprintf("%s, logs:\n%s", message, details.Logs)
+	}
+	return message
+}
+
 func (t *KeptnTask) SetStartTime() {
 	if t.Status.StartTime.IsZero() {
 		t.Status.StartTime = metav1.NewTime(time.Now().UTC())
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,6 +30,31 @@ import (
 // KeptnTaskDefinitionSpec defines the desired state of KeptnTaskDefinition
 type KeptnTaskDefinitionSpec struct {
 	Function FunctionSpec `json:"function,omitempty"`
+	// Container runs the task in a container of any image instead of the Deno function runtime,
+	// a definition must have either a function or a container, which is checked by the validating webhook
+	// +optional
+	Container *ContainerSpec `json:"container,omitempty"`
+	// PodTemplate customises the pod that runs the task, e.g. to satisfy PodSecurity or LimitRange policies,
+	// fields that are not set are taken from the taskPodTemplate of the KeptnConfig
+	// +optional
+	PodTemplate *TaskPodTemplate `json:"podTemplate,omitempty"`
+	// Timeout is the time an attempt of the task may take before it is stopped and counted as failed,
+	// attempts are not stopped if it is not set
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Retries is the number of times a failed task is started again, 6 by default like the backoff limit of a Job
+	// +kubebuilder:default:=6
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// RetryInterval is the time between a failed attempt of the task and the next one
+	// +kubebuilder:default:="10s"
+	// +kubebuilder:validation:Pattern="^0|([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
 }
 
 type FunctionSpec struct {
@@ -54,6 +83,61 @@ type HttpReference struct {
 }
 
 type ContainerSpec struct {
+	// Image is the container image that runs the task
+	Image string `json:"image"`
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Command overrides the entrypoint of the image
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args overrides the arguments of the entrypoint of the image
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env is added to the environment of the container, next to the CONTEXT, DATA and SECURE_DATA variables
+	// that are also passed to function tasks
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// VolumeMounts mount the volumes of the task into the container
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Volumes are added to the pod of the task
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// ServiceAccountName is the service account the pod of the task runs with, e.g. to use kubectl
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// TaskPodTemplate holds the settings of the pod of a task job that are not part of the task itself
+type TaskPodTemplate struct {
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// SecurityContext is the security context of the pod
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// Container holds the settings of the container that runs the task
+	// +optional
+	Container *TaskContainerTemplate `json:"container,omitempty"`
+}
+
+// TaskContainerTemplate holds the settings of the container of a task job that are not part of the task itself
+type TaskContainerTemplate struct {
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // KeptnTaskDefinitionStatus defines the observed state of KeptnTaskDefinition
@@ -94,3 +178,32 @@ type KeptnTaskDefinitionList struct {
 func init() {
 	SchemeBuilder.Register(&KeptnTaskDefinition{}, &KeptnTaskDefinitionList{})
 }
+
+// GetTimeout returns the timeout of an attempt of the task, 0 if the attempts have no timeout
+func (s KeptnTaskDefinitionSpec) GetTimeout() time.Duration {
+	if s.Timeout.Duration <= 0 {
+		return 0
+	}
+	return s.Timeout.Duration
+}
+
+// GetRetries returns the number of retries of the task, which defaults to 6
+func (s KeptnTaskDefinitionSpec) GetRetries() int32 {
+	if s.Retries == nil {
+		return 6
+	}
+	return *s.Retries
+}
+
+// GetRetryInterval returns the time between two attempts of the task, which defaults to 10s
+func (s KeptnTaskDefinitionSpec) GetRetryInterval() time.Duration {
+	if s.RetryInterval.Duration <= 0 {
+		return 10 * time.Second
+	}
+	return s.RetryInterval.Duration
+}
+
+// HasFunction returns whether the task runs a function, which is the case if any of its fields is set
+func (s KeptnTaskDefinitionSpec) HasFunction() bool {
+	return !reflect.DeepEqual(s.Function, FunctionSpec{})
+}
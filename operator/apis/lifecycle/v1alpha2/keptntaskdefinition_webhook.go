@@ -0,0 +1,62 @@
+package v1alpha2
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var keptntaskdefinitionlog = logf.Log.WithName("keptntaskdefinition-resource")
+
+func (r *KeptnTaskDefinition) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-lifecycle-keptn-sh-v1alpha2-keptntaskdefinition,mutating=false,failurePolicy=fail,sideEffects=None,groups=lifecycle.keptn.sh,resources=keptntaskdefinitions,verbs=create;update,versions=v1alpha2,name=vkeptntaskdefinition.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeptnTaskDefinition{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnTaskDefinition) ValidateCreate() error {
+	keptntaskdefinitionlog.Info("validate create", "name", r.Name)
+	return r.validateKeptnTaskDefinition()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnTaskDefinition) ValidateUpdate(old runtime.Object) error {
+	keptntaskdefinitionlog.Info("validate update", "name", r.Name)
+	return r.validateKeptnTaskDefinition()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *KeptnTaskDefinition) ValidateDelete() error {
+	keptntaskdefinitionlog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *KeptnTaskDefinition) validateKeptnTaskDefinition() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	hasFunction := r.Spec.HasFunction()
+	if hasFunction && r.Spec.Container != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("container"), "a task definition must not have both a function and a container"))
+	}
+	if !hasFunction && r.Spec.Container == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("function"), "a task definition must have either a function or a container"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "lifecycle.keptn.sh", Kind: "KeptnTaskDefinition"},
+		r.Name, allErrs)
+}
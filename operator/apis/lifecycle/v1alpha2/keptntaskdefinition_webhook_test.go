@@ -0,0 +1,58 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeptnTaskDefinition_validateKeptnTaskDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    KeptnTaskDefinitionSpec
+		wantErr string
+	}{
+		{
+			name: "function",
+			spec: KeptnTaskDefinitionSpec{
+				Function: FunctionSpec{Inline: Inline{Code: "console.log('hello')"}},
+			},
+		},
+		{
+			name: "container",
+			spec: KeptnTaskDefinitionSpec{
+				Container: &ContainerSpec{Image: "busybox"},
+			},
+		},
+		{
+			name: "function and container",
+			spec: KeptnTaskDefinitionSpec{
+				Function:  FunctionSpec{HttpReference: HttpReference{Url: "https://example.com/function.ts"}},
+				Container: &ContainerSpec{Image: "busybox"},
+			},
+			wantErr: "spec.container: Forbidden",
+		},
+		{
+			name:    "neither function nor container",
+			spec:    KeptnTaskDefinitionSpec{},
+			wantErr: "spec.function: Required value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := &KeptnTaskDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-definition"},
+				Spec:       tt.spec,
+			}
+			err := definition.ValidateCreate()
+			if tt.wantErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+			require.Contains(t, definition.ValidateUpdate(definition).Error(), tt.wantErr)
+		})
+	}
+}
@@ -38,6 +38,12 @@ type KeptnWorkloadSpec struct {
 	PreDeploymentEvaluations  []string          `json:"preDeploymentEvaluations,omitempty"`
 	PostDeploymentEvaluations []string          `json:"postDeploymentEvaluations,omitempty"`
 	ResourceReference         ResourceReference `json:"resourceReference"`
+	// TaskExecution defines the order in which the pre- and post-deployment tasks run, by default they run in parallel
+	// +optional
+	TaskExecution *TaskExecution `json:"taskExecution,omitempty"`
+	// OnFailureTasks are run once any phase of the deployment has failed, e.g. to notify, open an incident or roll back
+	// +optional
+	OnFailureTasks []string `json:"onFailureTasks,omitempty"`
 }
 
 // KeptnWorkloadStatus defines the observed state of KeptnWorkload
@@ -198,6 +198,7 @@ func TestKeptnWorkloadInstance(t *testing.T) {
 		AppName:              workload.GetAppName(),
 		WorkloadVersion:      workload.GetVersion(),
 		Workload:             workload.GetParentName(),
+		PreviousVersion:      "prev",
 		EvaluationDefinition: "taskdef",
 		Type:                 common.PostDeploymentCheckType,
 		RetryInterval: metav1.Duration{
@@ -406,3 +407,36 @@ func TestKeptnWorkloadInstanceList(t *testing.T) {
 	got := list.GetItems()
 	require.Len(t, got, 2)
 }
+
+func TestKeptnWorkloadInstance_OnFailure(t *testing.T) {
+	workload := &KeptnWorkloadInstance{
+		Spec: KeptnWorkloadInstanceSpec{
+			KeptnWorkloadSpec: KeptnWorkloadSpec{
+				OnFailureTasks: []string{"rollback"},
+			},
+		},
+		Status: KeptnWorkloadInstanceStatus{
+			OnFailureStatus: common.StateProgressing,
+			OnFailureTaskStatus: []ItemStatus{
+				{
+					DefinitionName: "rollback",
+					Status:         common.StateProgressing,
+					Name:           "on-failure-rollback-12345",
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []string{"rollback"}, workload.GetOnFailureTasks())
+	require.Equal(t, []ItemStatus{
+		{
+			DefinitionName: "rollback",
+			Status:         common.StateProgressing,
+			Name:           "on-failure-rollback-12345",
+		},
+	}, workload.GetOnFailureTaskStatus())
+	require.False(t, workload.IsOnFailureCompleted())
+
+	workload.Status.OnFailureStatus = common.StateSucceeded
+	require.True(t, workload.IsOnFailureCompleted())
+}
@@ -59,6 +59,10 @@ type KeptnWorkloadInstanceStatus struct {
 	EndTime                            metav1.Time         `json:"endTime,omitempty"`
 	CurrentPhase                       string              `json:"currentPhase,omitempty"`
 	PhaseTraceIDs                      common.PhaseTraceID `json:"phaseTraceIDs,omitempty"`
+	// OnFailureStatus is the state of the on-failure tasks, which run once a phase has failed
+	// +kubebuilder:default:=Pending
+	OnFailureStatus     common.KeptnState `json:"onFailureStatus,omitempty"`
+	OnFailureTaskStatus []ItemStatus      `json:"onFailureTaskStatus,omitempty"`
 	// +kubebuilder:default:=Pending
 	Status common.KeptnState `json:"status,omitempty"`
 }
@@ -166,6 +170,10 @@ func (w KeptnWorkloadInstance) IsPostDeploymentEvaluationFailed() bool {
 	return w.Status.PostDeploymentEvaluationStatus.IsFailed()
 }
 
+func (w KeptnWorkloadInstance) IsOnFailureCompleted() bool {
+	return w.Status.OnFailureStatus.IsCompleted()
+}
+
 func (w KeptnWorkloadInstance) IsDeploymentCompleted() bool {
 	return w.Status.DeploymentStatus.IsCompleted()
 }
@@ -262,6 +270,10 @@ func (w KeptnWorkloadInstance) GetPostDeploymentTasks() []string {
 	return w.Spec.PostDeploymentTasks
 }
 
+func (w KeptnWorkloadInstance) GetTaskExecution() *TaskExecution {
+	return w.Spec.TaskExecution
+}
+
 func (w KeptnWorkloadInstance) GetPreDeploymentTaskStatus() []ItemStatus {
 	return w.Status.PreDeploymentTaskStatus
 }
@@ -270,6 +282,14 @@ func (w KeptnWorkloadInstance) GetPostDeploymentTaskStatus() []ItemStatus {
 	return w.Status.PostDeploymentTaskStatus
 }
 
+func (w KeptnWorkloadInstance) GetOnFailureTasks() []string {
+	return w.Spec.OnFailureTasks
+}
+
+func (w KeptnWorkloadInstance) GetOnFailureTaskStatus() []ItemStatus {
+	return w.Status.OnFailureTaskStatus
+}
+
 func (w KeptnWorkloadInstance) GetPreDeploymentEvaluations() []string {
 	return w.Spec.PreDeploymentEvaluations
 }
@@ -346,6 +366,7 @@ func (w KeptnWorkloadInstance) GenerateEvaluation(evaluationDefinition string, c
 			AppName:              w.GetAppName(),
 			WorkloadVersion:      w.GetVersion(),
 			Workload:             w.GetParentName(),
+			PreviousVersion:      w.GetPreviousVersion(),
 			EvaluationDefinition: evaluationDefinition,
 			Type:                 checkType,
 			RetryInterval: metav1.Duration{
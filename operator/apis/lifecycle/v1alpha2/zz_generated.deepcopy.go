@@ -24,9 +24,26 @@ package v1alpha2
 import (
 	"github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	"go.opentelemetry.io/otel/propagation"
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuthConfig) DeepCopyInto(out *BasicAuthConfig) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthConfig.
+func (in *BasicAuthConfig) DeepCopy() *BasicAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
@@ -45,6 +62,45 @@ func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerSpec) DeepCopyInto(out *ContainerSpec) {
 	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSpec.
@@ -57,9 +113,92 @@ func (in *ContainerSpec) DeepCopy() *ContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynatraceOAuthConfig) DeepCopyInto(out *DynatraceOAuthConfig) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynatraceOAuthConfig.
+func (in *DynatraceOAuthConfig) DeepCopy() *DynatraceOAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DynatraceOAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynatraceProviderConfig) DeepCopyInto(out *DynatraceProviderConfig) {
+	*out = *in
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(DynatraceOAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynatraceProviderConfig.
+func (in *DynatraceProviderConfig) DeepCopy() *DynatraceProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DynatraceProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationAttempt) DeepCopyInto(out *EvaluationAttempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Objectives != nil {
+		in, out := &in.Objectives, &out.Objectives
+		*out = make([]ObjectiveResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationAttempt.
+func (in *EvaluationAttempt) DeepCopy() *EvaluationAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationBackoff) DeepCopyInto(out *EvaluationBackoff) {
+	*out = *in
+	out.MaxInterval = in.MaxInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationBackoff.
+func (in *EvaluationBackoff) DeepCopy() *EvaluationBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EvaluationStatusItem) DeepCopyInto(out *EvaluationStatusItem) {
 	*out = *in
+	if in.RawValue != nil {
+		in, out := &in.RawValue, &out.RawValue
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	in.QueryTime.DeepCopyInto(&out.QueryTime)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationStatusItem.
@@ -123,6 +262,28 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProviderConfig) DeepCopyInto(out *HTTPProviderConfig) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProviderConfig.
+func (in *HTTPProviderConfig) DeepCopy() *HTTPProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HttpReference) DeepCopyInto(out *HttpReference) {
 	*out = *in
@@ -257,6 +418,16 @@ func (in *KeptnAppSpec) DeepCopyInto(out *KeptnAppSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TaskExecution != nil {
+		in, out := &in.TaskExecution, &out.TaskExecution
+		*out = new(TaskExecution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OnFailureTasks != nil {
+		in, out := &in.OnFailureTasks, &out.OnFailureTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnAppSpec.
@@ -419,6 +590,13 @@ func (in *KeptnAppVersionStatus) DeepCopyInto(out *KeptnAppVersionStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.OnFailureTaskStatus != nil {
+		in, out := &in.OnFailureTaskStatus, &out.OnFailureTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
 }
@@ -438,7 +616,7 @@ func (in *KeptnEvaluation) DeepCopyInto(out *KeptnEvaluation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -525,8 +703,11 @@ func (in *KeptnEvaluationDefinitionSpec) DeepCopyInto(out *KeptnEvaluationDefini
 	if in.Objectives != nil {
 		in, out := &in.Objectives, &out.Objectives
 		*out = make([]Objective, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	out.TotalScore = in.TotalScore
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnEvaluationDefinitionSpec.
@@ -649,6 +830,37 @@ func (in *KeptnEvaluationProviderList) DeepCopyObject() runtime.Object {
 func (in *KeptnEvaluationProviderSpec) DeepCopyInto(out *KeptnEvaluationProviderSpec) {
 	*out = *in
 	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+	out.Timeout = in.Timeout
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ProviderTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(ProviderRateLimit)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginProviderConfig)
+		**out = **in
+	}
+	if in.Dynatrace != nil {
+		in, out := &in.Dynatrace, &out.Dynatrace
+		*out = new(DynatraceProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnEvaluationProviderSpec.
@@ -680,6 +892,13 @@ func (in *KeptnEvaluationProviderStatus) DeepCopy() *KeptnEvaluationProviderStat
 func (in *KeptnEvaluationSpec) DeepCopyInto(out *KeptnEvaluationSpec) {
 	*out = *in
 	out.RetryInterval = in.RetryInterval
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(EvaluationBackoff)
+		**out = **in
+	}
+	out.InitialDelay = in.InitialDelay
+	out.Timeout = in.Timeout
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnEvaluationSpec.
@@ -699,7 +918,14 @@ func (in *KeptnEvaluationStatus) DeepCopyInto(out *KeptnEvaluationStatus) {
 		in, out := &in.EvaluationStatus, &out.EvaluationStatus
 		*out = make(map[string]EvaluationStatusItem, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]EvaluationAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
@@ -806,6 +1032,23 @@ func (in *KeptnTaskDefinitionList) DeepCopyObject() runtime.Object {
 func (in *KeptnTaskDefinitionSpec) DeepCopyInto(out *KeptnTaskDefinitionSpec) {
 	*out = *in
 	in.Function.DeepCopyInto(&out.Function)
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(ContainerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(TaskPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Timeout = in.Timeout
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	out.RetryInterval = in.RetryInterval
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskDefinitionSpec.
@@ -869,9 +1112,16 @@ func (in *KeptnTaskList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeptnTaskSpec) DeepCopyInto(out *KeptnTaskSpec) {
 	*out = *in
-	out.Context = in.Context
+	in.Context.DeepCopyInto(&out.Context)
 	in.Parameters.DeepCopyInto(&out.Parameters)
 	out.SecureParameters = in.SecureParameters
+	out.Timeout = in.Timeout
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	out.RetryInterval = in.RetryInterval
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskSpec.
@@ -889,6 +1139,18 @@ func (in *KeptnTaskStatus) DeepCopyInto(out *KeptnTaskStatus) {
 	*out = *in
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailureDetails != nil {
+		in, out := &in.FailureDetails, &out.FailureDetails
+		*out = new(TaskFailureDetails)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnTaskStatus.
@@ -1060,6 +1322,13 @@ func (in *KeptnWorkloadInstanceStatus) DeepCopyInto(out *KeptnWorkloadInstanceSt
 			(*out)[key] = outVal
 		}
 	}
+	if in.OnFailureTaskStatus != nil {
+		in, out := &in.OnFailureTaskStatus, &out.OnFailureTaskStatus
+		*out = make([]ItemStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadInstanceStatus.
@@ -1143,6 +1412,16 @@ func (in *KeptnWorkloadSpec) DeepCopyInto(out *KeptnWorkloadSpec) {
 		copy(*out, *in)
 	}
 	out.ResourceReference = in.ResourceReference
+	if in.TaskExecution != nil {
+		in, out := &in.TaskExecution, &out.TaskExecution
+		*out = new(TaskExecution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OnFailureTasks != nil {
+		in, out := &in.OnFailureTasks, &out.OnFailureTasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeptnWorkloadSpec.
@@ -1173,6 +1452,16 @@ func (in *KeptnWorkloadStatus) DeepCopy() *KeptnWorkloadStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Objective) DeepCopyInto(out *Objective) {
 	*out = *in
+	if in.Range != nil {
+		in, out := &in.Range, &out.Range
+		*out = new(QueryRange)
+		**out = **in
+	}
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = new(ObjectiveComparison)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Objective.
@@ -1185,6 +1474,113 @@ func (in *Objective) DeepCopy() *Objective {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectiveComparison) DeepCopyInto(out *ObjectiveComparison) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectiveComparison.
+func (in *ObjectiveComparison) DeepCopy() *ObjectiveComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectiveComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectiveResult) DeepCopyInto(out *ObjectiveResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectiveResult.
+func (in *ObjectiveResult) DeepCopy() *ObjectiveResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectiveResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProviderConfig) DeepCopyInto(out *PluginProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProviderConfig.
+func (in *PluginProviderConfig) DeepCopy() *PluginProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderRateLimit) DeepCopyInto(out *ProviderRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRateLimit.
+func (in *ProviderRateLimit) DeepCopy() *ProviderRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderTLSConfig) DeepCopyInto(out *ProviderTLSConfig) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTLSConfig.
+func (in *ProviderTLSConfig) DeepCopy() *ProviderTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRange) DeepCopyInto(out *QueryRange) {
+	*out = *in
+	out.Interval = in.Interval
+	out.Step = in.Step
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRange.
+func (in *QueryRange) DeepCopy() *QueryRange {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -1215,9 +1611,51 @@ func (in *SecureParameters) DeepCopy() *SecureParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskContainerTemplate) DeepCopyInto(out *TaskContainerTemplate) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskContainerTemplate.
+func (in *TaskContainerTemplate) DeepCopy() *TaskContainerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskContainerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskContext) DeepCopyInto(out *TaskContext) {
 	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskContext.
@@ -1230,6 +1668,51 @@ func (in *TaskContext) DeepCopy() *TaskContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecution) DeepCopyInto(out *TaskExecution) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecution.
+func (in *TaskExecution) DeepCopy() *TaskExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskFailureDetails) DeepCopyInto(out *TaskFailureDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskFailureDetails.
+func (in *TaskFailureDetails) DeepCopy() *TaskFailureDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskFailureDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParameters) DeepCopyInto(out *TaskParameters) {
 	*out = *in
@@ -1252,6 +1735,70 @@ func (in *TaskParameters) DeepCopy() *TaskParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskPodTemplate) DeepCopyInto(out *TaskPodTemplate) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(TaskContainerTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskPodTemplate.
+func (in *TaskPodTemplate) DeepCopy() *TaskPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TotalScore) DeepCopyInto(out *TotalScore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TotalScore.
+func (in *TotalScore) DeepCopy() *TotalScore {
+	if in == nil {
+		return nil
+	}
+	out := new(TotalScore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 	*out = *in
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	lifecyclecommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	"github.com/keptn/lifecycle-toolkit/operator/apis/metrics/v1alpha1/common"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,6 +66,9 @@ func (s *KeptnMetric) validateKeptnMetric() error {
 	if err = s.validateProvider(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err = s.validateQuery(); err != nil {
+		allErrs = append(allErrs, err)
+	}
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -87,3 +91,14 @@ func (s *KeptnMetric) validateProvider() *field.Error {
 	}
 	return nil
 }
+
+func (s *KeptnMetric) validateQuery() *field.Error {
+	if err := lifecyclecommon.ValidateMetricQuery(s.Spec.Query); err != nil {
+		return field.Invalid(
+			field.NewPath("spec").Child("query"),
+			s.Spec.Query,
+			err.Error(),
+		)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -15,6 +16,7 @@ func TestKeptnMetric_validateKeptnMetric(t *testing.T) {
 	tests := []struct {
 		name         string
 		providerName string
+		query        string
 		want         error
 	}{
 		{
@@ -27,12 +29,29 @@ func TestKeptnMetric_validateKeptnMetric(t *testing.T) {
 			name:         "good-provider",
 			providerName: "prometheus",
 		},
+		{
+			name:         "bad-query",
+			providerName: "prometheus",
+			query:        "{{.Unknown}}",
+			want:         errors.New("spec.query"),
+		},
+		{
+			name:         "deployment-placeholder",
+			providerName: "prometheus",
+			query:        `up{service="{{.Workload}}"}`,
+			want:         errors.New("spec.query"),
+		},
+		{
+			name:         "namespace-placeholder",
+			providerName: "prometheus",
+			query:        `up{namespace="{{.Namespace}}"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &KeptnMetric{
 				ObjectMeta: metav1.ObjectMeta{Name: tt.name},
-				Spec:       KeptnMetricSpec{Provider: ProviderRef{Name: tt.providerName}},
+				Spec:       KeptnMetricSpec{Provider: ProviderRef{Name: tt.providerName}, Query: tt.query},
 			}
 			got := r.validateKeptnMetric()
 			if tt.want != nil {
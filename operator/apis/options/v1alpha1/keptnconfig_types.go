@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	lifecyclev1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,6 +31,9 @@ type KeptnConfigSpec struct {
 
 	// OTelCollectorUrl can be used to set the Open Telemetry collector that the operator should use
 	OTelCollectorUrl string `json:"OTelCollectorUrl,omitempty"`
+	// TaskPodTemplate holds the defaults for the pods of all KeptnTasks, which KeptnTaskDefinitions can override
+	// +optional
+	TaskPodTemplate *lifecyclev1alpha2.TaskPodTemplate `json:"taskPodTemplate,omitempty"`
 }
 
 // KeptnConfigStatus defines the observed state of KeptnConfig
@@ -182,10 +182,20 @@ func (r EvaluationHandler) handleEvaluationExists(phaseCtx context.Context, piWr
 	// Update state of Evaluation if it is already created
 	evaluationStatus.Status = evaluation.Status.OverallStatus
 	if evaluationStatus.Status.IsCompleted() {
-		if evaluationStatus.Status.IsSucceeded() {
+		if evaluationStatus.Status.IsSucceeded() && evaluation.Status.Warning {
+			spanEvaluationTrace.AddEvent(evaluation.Name + " has finished with warning, score: " + evaluation.Status.Score)
+			spanEvaluationTrace.SetStatus(codes.Ok, "Finished")
+			RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "Succeeded", "evaluation succeeded with warning", piWrapper.GetVersion())
+		} else if evaluationStatus.Status.IsSucceeded() {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has finished")
 			spanEvaluationTrace.SetStatus(codes.Ok, "Finished")
 			RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Normal", evaluation, "Succeeded", "evaluation succeeded", piWrapper.GetVersion())
+		} else if evaluation.Status.FailAction == klcv1alpha2.FailActionIgnore {
+			// the evaluation keeps its result, but the phase continues as if it had succeeded
+			spanEvaluationTrace.AddEvent(evaluation.Name + " has failed, failAction is ignore")
+			spanEvaluationTrace.SetStatus(codes.Ok, "Failure ignored")
+			RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Normal", evaluation, "FailureIgnored", "evaluation failed, failAction is ignore", piWrapper.GetVersion())
+			evaluationStatus.Status = apicommon.StateSucceeded
 		} else {
 			spanEvaluationTrace.AddEvent(evaluation.Name + " has failed")
 			r.emitEvaluationFailureEvents(evaluation, spanEvaluationTrace, piWrapper)
@@ -232,6 +232,111 @@ func TestEvaluationHandler(t *testing.T) {
 				"ReconcileEvaluationSucceeded",
 			},
 		},
+		{
+			name: "succeeded evaluation with warning",
+			object: &v1alpha2.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1alpha2.KeptnAppVersionSpec{
+					KeptnAppSpec: v1alpha2.KeptnAppSpec{
+						PreDeploymentEvaluations: []string{"eval-def"},
+					},
+				},
+				Status: v1alpha2.KeptnAppVersionStatus{
+					PreDeploymentEvaluationTaskStatus: []v1alpha2.ItemStatus{
+						{
+							DefinitionName: "eval-def",
+							Status:         apicommon.StateProgressing,
+							Name:           "pre-eval-eval-def-",
+						},
+					},
+				},
+			},
+			evalObj: v1alpha2.KeptnEvaluation{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "pre-eval-eval-def-",
+				},
+				Spec: v1alpha2.KeptnEvaluationSpec{
+					FailAction: v1alpha2.FailActionWarn,
+				},
+				Status: v1alpha2.KeptnEvaluationStatus{
+					OverallStatus: apicommon.StateSucceeded,
+					Warning:       true,
+				},
+			},
+			createAttr: CreateAttributes{
+				SpanName:   "",
+				Definition: "eval-def",
+				CheckType:  apicommon.PreDeploymentEvaluationCheckType,
+			},
+			wantStatus: []v1alpha2.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StateSucceeded,
+					Name:           "pre-eval-eval-def-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Succeeded: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 1,
+			events: []string{
+				"Warning ReconcileEvaluationSucceeded",
+			},
+		},
+		{
+			name: "failed evaluation with fail action ignore",
+			object: &v1alpha2.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: v1alpha2.KeptnAppVersionSpec{
+					KeptnAppSpec: v1alpha2.KeptnAppSpec{
+						PreDeploymentEvaluations: []string{"eval-def"},
+					},
+				},
+				Status: v1alpha2.KeptnAppVersionStatus{
+					PreDeploymentEvaluationTaskStatus: []v1alpha2.ItemStatus{
+						{
+							DefinitionName: "eval-def",
+							Status:         apicommon.StateProgressing,
+							Name:           "pre-eval-eval-def-",
+						},
+					},
+				},
+			},
+			evalObj: v1alpha2.KeptnEvaluation{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "pre-eval-eval-def-",
+				},
+				Status: v1alpha2.KeptnEvaluationStatus{
+					OverallStatus: apicommon.StateFailed,
+					FailAction:    v1alpha2.FailActionIgnore,
+				},
+			},
+			createAttr: CreateAttributes{
+				SpanName:   "",
+				Definition: "eval-def",
+				CheckType:  apicommon.PreDeploymentEvaluationCheckType,
+			},
+			wantStatus: []v1alpha2.ItemStatus{
+				{
+					DefinitionName: "eval-def",
+					Status:         apicommon.StateSucceeded,
+					Name:           "pre-eval-eval-def-",
+				},
+			},
+			wantSummary:     apicommon.StatusSummary{Total: 1, Succeeded: 1},
+			wantErr:         nil,
+			getSpanCalls:    1,
+			unbindSpanCalls: 1,
+			events: []string{
+				"ReconcileEvaluationFailureIgnored",
+			},
+		},
 	}
 
 	for _, tt := range tests {
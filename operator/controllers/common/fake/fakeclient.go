@@ -15,8 +15,14 @@ import (
 
 // NewClient returns a new controller-runtime fake Client configured with the Operator's scheme, and initialized with objs.
 func NewClient(objs ...client.Object) client.Client {
+	return NewClientBuilder().WithObjects(objs...).Build()
+}
+
+// NewClientBuilder returns a controller-runtime fake ClientBuilder configured with the Operator's scheme, e.g. for
+// clients that need field indexes.
+func NewClientBuilder() *fake.ClientBuilder {
 	setupSchemes()
-	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme)
 }
 
 func setupSchemes() {
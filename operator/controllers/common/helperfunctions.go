@@ -1,12 +1,14 @@
 package common
 
 import (
+	"context"
 	"fmt"
 
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
 	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	"github.com/keptn/lifecycle-toolkit/operator/controllers/lifecycle/interfaces"
 	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +34,35 @@ func GetItemStatus(name string, instanceStatus []klcv1alpha2.ItemStatus) klcv1al
 	}
 }
 
+// GetTaskOutputs returns the outputs of the succeeded tasks of the application or workload version
+// that the given task or evaluation belongs to, by the name of their task definition
+func GetTaskOutputs(ctx context.Context, c client.Client, obj client.Object) (map[string]map[string]string, error) {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil {
+		return nil, nil
+	}
+	tasks := &klcv1alpha2.KeptnTaskList{}
+	if err := c.List(ctx, tasks, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, err
+	}
+
+	var outputs map[string]map[string]string
+	for _, task := range tasks.Items {
+		taskOwner := metav1.GetControllerOf(&task)
+		if task.Name == obj.GetName() || taskOwner == nil || taskOwner.UID != owner.UID {
+			continue
+		}
+		if !task.Status.Status.IsSucceeded() || len(task.Status.Output) == 0 {
+			continue
+		}
+		if outputs == nil {
+			outputs = make(map[string]map[string]string)
+		}
+		outputs[task.Spec.TaskDefinition] = task.Status.Output
+	}
+	return outputs, nil
+}
+
 func GetAppVersionName(namespace string, appName string, version string) types.NamespacedName {
 	return types.NamespacedName{Namespace: namespace, Name: appName + "-" + version}
 }
@@ -89,6 +89,10 @@ func (r PhaseHandler) handleCompletedPhase(state apicommon.KeptnState, piWrapper
 		}
 		RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "Failed", "has failed", piWrapper.GetVersion())
 		piWrapper.DeprecateRemainingPhases(phase)
+		// the on-failure tasks are started in the next reconciliation
+		if len(piWrapper.GetOnFailureTasks()) > 0 {
+			return &PhaseResult{Continue: false, Result: ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}}, nil
+		}
 		return &PhaseResult{Continue: false, Result: ctrl.Result{}}, nil
 	}
 
@@ -103,3 +107,49 @@ func (r PhaseHandler) handleCompletedPhase(state apicommon.KeptnState, piWrapper
 
 	return &PhaseResult{Continue: true, Result: ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}}, nil
 }
+
+// HandleOnFailurePhase runs the on-failure tasks of a reconcile object after one of its phases has failed.
+// Unlike HandlePhase it does not change the state of the object, which stays failed whatever the outcome of the tasks.
+func (r PhaseHandler) HandleOnFailurePhase(ctx context.Context, ctxTrace context.Context, tracer trace.Tracer, reconcileObject client.Object, phase apicommon.KeptnPhaseType, span trace.Span, reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)) (*PhaseResult, error) {
+	requeueResult := ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(reconcileObject)
+	if err != nil {
+		return &PhaseResult{Continue: false, Result: ctrl.Result{Requeue: true}}, err
+	}
+	// the current phase is written together with the status of the tasks
+	piWrapper.SetCurrentPhase(phase.ShortName)
+
+	spanPhaseCtx, spanPhaseTrace, err := r.SpanHandler.GetSpan(ctxTrace, tracer, reconcileObject, phase.ShortName)
+	if err != nil {
+		r.Log.Error(err, "could not get span")
+	}
+
+	state, err := reconcilePhase(spanPhaseCtx)
+	if err != nil {
+		spanPhaseTrace.AddEvent(phase.LongName + " could not get reconciled")
+		RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "ReconcileErrored", "could not get reconciled", piWrapper.GetVersion())
+		span.SetStatus(codes.Error, err.Error())
+		return &PhaseResult{Continue: false, Result: requeueResult}, err
+	}
+
+	if !state.IsCompleted() {
+		RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "NotFinished", "has not finished", piWrapper.GetVersion())
+		return &PhaseResult{Continue: false, Result: requeueResult}, nil
+	}
+
+	if state.IsSucceeded() {
+		spanPhaseTrace.AddEvent(phase.LongName + " has succeeded")
+		spanPhaseTrace.SetStatus(codes.Ok, "Succeeded")
+		RecordEvent(r.Recorder, phase, "Normal", reconcileObject, "Succeeded", "has succeeded", piWrapper.GetVersion())
+	} else {
+		spanPhaseTrace.AddEvent(phase.LongName + " has failed")
+		spanPhaseTrace.SetStatus(codes.Error, "Failed")
+		RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "Failed", "has failed", piWrapper.GetVersion())
+	}
+	spanPhaseTrace.End()
+	if err := r.SpanHandler.UnbindSpan(reconcileObject, phase.ShortName); err != nil {
+		r.Log.Error(err, controllererrors.ErrCouldNotUnbindSpan, reconcileObject.GetName())
+	}
+
+	return &PhaseResult{Continue: false, Result: ctrl.Result{}}, nil
+}
@@ -184,6 +184,39 @@ func TestPhaseHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reconcilePhase failed state with on-failure tasks",
+			handler: PhaseHandler{
+				SpanHandler: &SpanHandler{},
+				Log:         ctrl.Log.WithName("controller"),
+				Recorder:    record.NewFakeRecorder(100),
+				Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			},
+			object: &v1alpha2.KeptnAppVersion{
+				Spec: v1alpha2.KeptnAppVersionSpec{
+					KeptnAppSpec: v1alpha2.KeptnAppSpec{
+						OnFailureTasks: []string{"rollback"},
+					},
+				},
+				Status: v1alpha2.KeptnAppVersionStatus{
+					Status:       apicommon.StateProgressing,
+					CurrentPhase: apicommon.PhaseAppPostDeployment.LongName,
+				},
+			},
+			phase: apicommon.PhaseAppPostDeployment,
+			reconcilePhase: func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+				return apicommon.StateFailed, nil
+			},
+			want:    &PhaseResult{Continue: false, Result: requeueResult},
+			wantErr: nil,
+			wantObject: &v1alpha2.KeptnAppVersion{
+				Status: v1alpha2.KeptnAppVersionStatus{
+					Status:       apicommon.StateFailed,
+					CurrentPhase: apicommon.PhaseAppPostDeployment.ShortName,
+					EndTime:      v1.Time{Time: time.Now().UTC()},
+				},
+			},
+		},
 		{
 			name: "reconcilePhase unknown state",
 			handler: PhaseHandler{
@@ -224,3 +257,73 @@ func TestPhaseHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPhaseHandler_HandleOnFailurePhase(t *testing.T) {
+	requeueResult := ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}
+	tests := []struct {
+		name           string
+		reconcilePhase func(phaseCtx context.Context) (apicommon.KeptnState, error)
+		want           *PhaseResult
+		wantErr        error
+		wantEvent      string
+	}{
+		{
+			name: "reconcilePhase error",
+			reconcilePhase: func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+				return "", fmt.Errorf("some err")
+			},
+			want:      &PhaseResult{Continue: false, Result: requeueResult},
+			wantErr:   fmt.Errorf("some err"),
+			wantEvent: "AppOnFailureTasksReconcileErrored",
+		},
+		{
+			name: "reconcilePhase progressing state",
+			reconcilePhase: func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+				return apicommon.StateProgressing, nil
+			},
+			want:      &PhaseResult{Continue: false, Result: requeueResult},
+			wantEvent: "AppOnFailureTasksNotFinished",
+		},
+		{
+			name: "reconcilePhase succeeded state",
+			reconcilePhase: func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+				return apicommon.StateSucceeded, nil
+			},
+			want:      &PhaseResult{Continue: false, Result: ctrl.Result{}},
+			wantEvent: "AppOnFailureTasksSucceeded",
+		},
+		{
+			name: "reconcilePhase failed state",
+			reconcilePhase: func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+				return apicommon.StateFailed, nil
+			},
+			want:      &PhaseResult{Continue: false, Result: ctrl.Result{}},
+			wantEvent: "AppOnFailureTasksFailed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(100)
+			handler := PhaseHandler{
+				SpanHandler: &SpanHandler{},
+				Log:         ctrl.Log.WithName("controller"),
+				Recorder:    recorder,
+				Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			}
+			object := &v1alpha2.KeptnAppVersion{
+				Status: v1alpha2.KeptnAppVersionStatus{
+					Status:       apicommon.StateFailed,
+					CurrentPhase: apicommon.PhaseAppPostDeployment.ShortName,
+				},
+			}
+
+			result, err := handler.HandleOnFailurePhase(context.TODO(), context.TODO(), trace.NewNoopTracerProvider().Tracer("tracer"), object, apicommon.PhaseAppOnFailure, trace.SpanFromContext(context.TODO()), tt.reconcilePhase)
+			require.Equal(t, tt.want, result)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, apicommon.StateFailed, object.Status.Status)
+			require.Equal(t, apicommon.PhaseAppOnFailure.ShortName, object.Status.CurrentPhase)
+			require.Contains(t, <-recorder.Events, tt.wantEvent)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Aggregate reduces the given values, in chronological order, to a single value. It is used for the data points of
+// range queries as well as for the baselines of relative targets.
+func Aggregate(function string, values []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no values to aggregate")
+	}
+	switch function {
+	case "avg", "":
+		return sum(values) / float64(len(values)), nil
+	case "sum":
+		return sum(values), nil
+	case "count":
+		return float64(len(values)), nil
+	case "last":
+		return values[len(values)-1], nil
+	case "min":
+		return percentile(values, 0), nil
+	case "max":
+		return percentile(values, 100), nil
+	case "p50", "median":
+		return percentile(values, 50), nil
+	case "p90":
+		return percentile(values, 90), nil
+	case "p95":
+		return percentile(values, 95), nil
+	case "p99":
+		return percentile(values, 99), nil
+	default:
+		return 0, fmt.Errorf("aggregation %s not supported", function)
+	}
+}
+
+func sum(values []float64) float64 {
+	var s float64
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
+
+// percentile uses linear interpolation between the closest ranks
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
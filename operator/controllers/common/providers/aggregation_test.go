@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []float64{4, 1, 3, 2, 5, 10, 6, 7, 9, 8}
+	tests := []struct {
+		function  string
+		values    []float64
+		want      float64
+		wantError bool
+	}{
+		{function: "avg", values: values, want: 5.5},
+		{function: "", values: values, want: 5.5},
+		{function: "sum", values: values, want: 55},
+		{function: "count", values: values, want: 10},
+		{function: "last", values: values, want: 8},
+		{function: "min", values: values, want: 1},
+		{function: "max", values: values, want: 10},
+		{function: "p50", values: values, want: 5.5},
+		{function: "median", values: []float64{3, 1, 2}, want: 2},
+		{function: "p90", values: values, want: 9.1},
+		{function: "p99", values: []float64{42}, want: 42},
+		{function: "avg", values: []float64{}, wantError: true},
+		{function: "mode", values: values, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			got, err := Aggregate(tt.function, tt.values)
+			if tt.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 0.000001)
+		})
+	}
+}
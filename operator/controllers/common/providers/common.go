@@ -1,12 +1,19 @@
 package providers
 
 import (
+	"context"
+
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const DynatraceProviderName = "dynatrace"
 const PrometheusProviderName = "prometheus"
+const DatadogProviderName = "datadog"
+const HTTPProviderName = "http"
+const PluginProviderName = "plugin"
 const KeptnMetricProviderName = "keptn-metric"
 const KLTNamespace = "keptn-lifecycle-toolkit-system"
 
@@ -16,3 +23,16 @@ var MetricDefaultProvider = &klcv1alpha2.KeptnEvaluationProvider{
 		Namespace: KLTNamespace,
 	},
 }
+
+// EvictProvider drops the cached transport, connection, access token and rate limiter of a deleted provider
+func EvictProvider(provider types.NamespacedName) {
+	providerTransports.evict(provider)
+	providerConns.evict(provider)
+	providerTokenSources.evict(provider)
+	providerRateLimiters.evict(provider)
+}
+
+// getSecretValue returns the value of the secret key referenced by the provider
+func getSecretValue(ctx context.Context, k8sClient client.Client, provider klcv1alpha2.KeptnEvaluationProvider) (string, error) {
+	return getSecretKeyValue(ctx, k8sClient, provider.Namespace, provider.Spec.SecretKeyRef)
+}
@@ -0,0 +1,148 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DatadogAppKeyName is the key of the secret referenced by the provider that holds the Datadog application key,
+// the API key is taken from the key set in SecretKeyRef
+const DatadogAppKeyName = "DD_CLIENT_APP_KEY"
+
+type KeptnDatadogProvider struct {
+	Log        logr.Logger
+	httpClient http.Client
+	k8sClient  client.Client
+}
+
+type DatadogResponse struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	Series []DatadogSerie `json:"series"`
+}
+
+type DatadogSerie struct {
+	Metric string `json:"metric"`
+	// Pointlist contains [timestamp, value] pairs, the value may be null
+	Pointlist [][]*float64 `json:"pointlist"`
+}
+
+// EvaluateQuery fetches the SLI values from datadog provider
+func (d *KeptnDatadogProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, provider.GetTimeout())
+	defer cancel()
+
+	queryRange := klcv1alpha2.QueryRange{}
+	if objective.Range != nil {
+		queryRange = *objective.Range
+	}
+	to := time.Now().UTC()
+	from := to.Add(-queryRange.GetInterval())
+	qURL := provider.Spec.TargetServer + "/api/v1/query?from=" + strconv.FormatInt(from.Unix(), 10) +
+		"&to=" + strconv.FormatInt(to.Unix(), 10) + "&query=" + url.QueryEscape(objective.Query)
+
+	d.Log.Info("Running query: " + qURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", qURL, nil)
+	if err != nil {
+		d.Log.Error(err, "Error while creating request")
+		return "", nil, err
+	}
+
+	apiKey, appKey, err := d.getDDKeys(ctx, provider)
+	if err != nil {
+		return "", nil, err
+	}
+
+	httpClient, err := configureHTTPClient(ctx, d.k8sClient, d.httpClient, provider)
+	if err != nil {
+		return "", nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("DD-API-KEY", apiKey)
+	req.Header.Set("DD-APPLICATION-KEY", appKey)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		d.Log.Error(err, "Error while creating request")
+		return "", nil, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			d.Log.Error(err, "Could not close request body")
+		}
+	}()
+
+	// we ignore the error here because we fail later while unmarshalling
+	b, _ := io.ReadAll(res.Body)
+	result := DatadogResponse{}
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		d.Log.Error(err, "Error while parsing response")
+		return "", b, err
+	}
+	if result.Error != "" {
+		return "", b, fmt.Errorf("datadog query failed: %s", result.Error)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", b, fmt.Errorf("datadog query failed with status %d", res.StatusCode)
+	}
+
+	value, err := d.getSingleValue(result, queryRange.GetAggregation())
+	if err != nil {
+		return "", b, err
+	}
+	return fmt.Sprintf("%f", value), b, nil
+}
+
+// getSingleValue reduces the data points of the only series in the result to a single value
+func (d *KeptnDatadogProvider) getSingleValue(result DatadogResponse, aggregation string) (float64, error) {
+	if len(result.Series) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	} else if len(result.Series) > 1 {
+		return 0, fmt.Errorf("too many values in the query result")
+	}
+	var values []float64
+	for _, point := range result.Series[0].Pointlist {
+		if len(point) == 2 && point[1] != nil {
+			values = append(values, *point[1])
+		}
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	}
+	return Aggregate(aggregation, values)
+}
+
+func (d *KeptnDatadogProvider) getDDKeys(ctx context.Context, provider klcv1alpha2.KeptnEvaluationProvider) (string, string, error) {
+	if !provider.HasSecretDefined() {
+		return "", "", errors.New("the SecretKeyRef property with the Datadog API and application keys is missing")
+	}
+	ddCredsSecret := &corev1.Secret{}
+	if err := d.k8sClient.Get(ctx, types.NamespacedName{Name: provider.Spec.SecretKeyRef.Name, Namespace: provider.Namespace}, ddCredsSecret); err != nil {
+		return "", "", err
+	}
+
+	apiKey := ddCredsSecret.Data[provider.Spec.SecretKeyRef.Key]
+	if len(apiKey) == 0 {
+		return "", "", fmt.Errorf("secret contains invalid key %s", provider.Spec.SecretKeyRef.Key)
+	}
+	appKey := ddCredsSecret.Data[DatadogAppKeyName]
+	if len(appKey) == 0 {
+		return "", "", fmt.Errorf("secret contains invalid key %s", DatadogAppKeyName)
+	}
+	return string(apiKey), string(appKey), nil
+}
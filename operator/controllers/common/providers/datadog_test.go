@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const ddPayload = "{\"status\":\"ok\",\"res_type\":\"time_series\",\"series\":[{\"metric\":\"system.cpu.idle\",\"display_name\":\"system.cpu.idle\",\"pointlist\":[[1666090140000,10.0],[1666090200000,null],[1666090260000,20.0],[1666090320000,60.0]],\"scope\":\"host:my-host\",\"interval\":60,\"length\":4}],\"query\":\"avg:system.cpu.idle{host:my-host}\",\"from_date\":1666090140000,\"to_date\":1666090380000,\"group_by\":[]}"
+const ddEmptyPayload = "{\"status\":\"ok\",\"res_type\":\"time_series\",\"series\":[],\"query\":\"avg:system.cpu.idle{host:my-host}\"}"
+const ddMultiSeriesPayload = "{\"status\":\"ok\",\"series\":[{\"metric\":\"system.cpu.idle\",\"pointlist\":[[1666090140000,10.0]]},{\"metric\":\"system.cpu.idle\",\"pointlist\":[[1666090140000,20.0]]}]}"
+const ddErrorPayload = "{\"status\":\"error\",\"error\":\"Error parsing query: unable to parse garbage\"}"
+
+func newDatadogTestProvider(secretData map[string][]byte) KeptnDatadogProvider {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ddcreds",
+		},
+		Data: secretData,
+	}
+	return KeptnDatadogProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(secret),
+	}
+}
+
+func newDatadogEvaluationProvider(targetServer string) klcv1alpha2.KeptnEvaluationProvider {
+	return klcv1alpha2.KeptnEvaluationProvider{
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "ddcreds",
+				},
+				Key: "DD_CLIENT_API_KEY",
+			},
+			TargetServer: targetServer,
+		},
+	}
+}
+
+func TestDatadog_EvaluateQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		status    int
+		objective klcv1alpha2.Objective
+		out       string
+		wantError bool
+	}{
+		{
+			name:      "happy path, null values are skipped",
+			in:        ddPayload,
+			status:    http.StatusOK,
+			objective: klcv1alpha2.Objective{Query: "avg:system.cpu.idle{host:my-host}"},
+			out:       fmt.Sprintf("%f", 30.0),
+		},
+		{
+			name:   "aggregation of the range",
+			in:     ddPayload,
+			status: http.StatusOK,
+			objective: klcv1alpha2.Objective{
+				Query: "avg:system.cpu.idle{host:my-host}",
+				Range: &klcv1alpha2.QueryRange{Aggregation: "max"},
+			},
+			out: fmt.Sprintf("%f", 60.0),
+		},
+		{
+			name:      "empty series",
+			in:        ddEmptyPayload,
+			status:    http.StatusOK,
+			objective: klcv1alpha2.Objective{Query: "avg:system.cpu.idle{host:my-host}"},
+			wantError: true,
+		},
+		{
+			name:      "multiple series",
+			in:        ddMultiSeriesPayload,
+			status:    http.StatusOK,
+			objective: klcv1alpha2.Objective{Query: "avg:system.cpu.idle{*} by {host}"},
+			wantError: true,
+		},
+		{
+			name:      "query error",
+			in:        ddErrorPayload,
+			status:    http.StatusBadRequest,
+			objective: klcv1alpha2.Objective{Query: "garbage"},
+			wantError: true,
+		},
+		{
+			name:      "wrong payload",
+			in:        "garbage",
+			status:    http.StatusOK,
+			objective: klcv1alpha2.Objective{Query: "garbage"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "GET", r.Method)
+				require.Equal(t, "/api/v1/query", r.URL.Path)
+				require.Equal(t, tt.objective.Query, r.URL.Query().Get("query"))
+				require.NotEmpty(t, r.URL.Query().Get("from"))
+				require.NotEmpty(t, r.URL.Query().Get("to"))
+				require.Equal(t, "myapikey", r.Header.Get("DD-API-KEY"))
+				require.Equal(t, "myappkey", r.Header.Get("DD-APPLICATION-KEY"))
+				w.WriteHeader(tt.status)
+				_, err := w.Write([]byte(tt.in))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kdd := newDatadogTestProvider(map[string][]byte{
+				"DD_CLIENT_API_KEY": []byte("myapikey"),
+				DatadogAppKeyName:   []byte("myappkey"),
+			})
+			r, raw, e := kdd.EvaluateQuery(context.TODO(), tt.objective, newDatadogEvaluationProvider(svr.URL))
+			if tt.wantError {
+				require.NotNil(t, e)
+				require.Equal(t, "", r)
+				// the response is kept to find out why the query failed
+				require.Equal(t, []byte(tt.in), raw)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, tt.out, r)
+			require.Equal(t, []byte(tt.in), raw)
+		})
+	}
+}
+
+func TestDatadog_EvaluateQuery_Secrets(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(ddPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+	obj := klcv1alpha2.Objective{
+		Query: "avg:system.cpu.idle{host:my-host}",
+	}
+
+	kdd := newDatadogTestProvider(map[string][]byte{"DD_CLIENT_API_KEY": []byte("myapikey")})
+	_, _, e := kdd.EvaluateQuery(context.TODO(), obj, klcv1alpha2.KeptnEvaluationProvider{
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{TargetServer: svr.URL},
+	})
+	require.NotNil(t, e)
+	require.True(t, strings.Contains(e.Error(), "the SecretKeyRef property with the Datadog API and application keys is missing"))
+
+	_, _, e = kdd.EvaluateQuery(context.TODO(), obj, newDatadogEvaluationProvider(svr.URL))
+	require.NotNil(t, e)
+	require.True(t, strings.Contains(e.Error(), "invalid key "+DatadogAppKeyName))
+
+	kdd = newDatadogTestProvider(map[string][]byte{DatadogAppKeyName: []byte("myappkey")})
+	_, _, e = kdd.EvaluateQuery(context.TODO(), obj, newDatadogEvaluationProvider(svr.URL))
+	require.NotNil(t, e)
+	require.True(t, strings.Contains(e.Error(), "invalid key DD_CLIENT_API_KEY"))
+
+	kdd.k8sClient = fake.NewClient()
+	_, _, e = kdd.EvaluateQuery(context.TODO(), obj, newDatadogEvaluationProvider(svr.URL))
+	require.NotNil(t, e)
+	require.True(t, errors.IsNotFound(e))
+}
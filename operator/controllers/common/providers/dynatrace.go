@@ -7,15 +7,25 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// providerTokenSources keeps the OAuth token source of each Dynatrace provider, so that an access token is reused by
+// the queries of all evaluations until it expires instead of a new one being fetched for each query
+var providerTokenSources = &tokenSourceCache{sources: map[types.NamespacedName]cachedTokenSource{}}
+
 type KeptnDynatraceProvider struct {
 	Log        logr.Logger
 	httpClient http.Client
@@ -40,24 +50,45 @@ type DynatraceData struct {
 
 // EvaluateQuery fetches the SLI values from dynatrace provider
 func (d *KeptnDynatraceProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
-	qURL := provider.Spec.TargetServer + "/api/v2/metrics/query?metricSelector=" + objective.Query
+	ctx, cancel := context.WithTimeout(ctx, provider.GetTimeout())
+	defer cancel()
+
+	httpClient, err := configureHTTPClient(ctx, d.k8sClient, d.httpClient, provider)
+	if err != nil {
+		return "", nil, err
+	}
+
+	config := klcv1alpha2.DynatraceProviderConfig{}
+	if provider.Spec.Dynatrace != nil {
+		config = *provider.Spec.Dynatrace
+	}
+	if config.GetAPI() == "dql" {
+		return d.evaluateDQL(ctx, &httpClient, objective, provider, config)
+	}
+
+	qURL := provider.Spec.TargetServer + "/api/v2/metrics/query?"
+	aggregation := ""
+	if objective.Range != nil {
+		from := time.Now().Add(-objective.Range.GetInterval())
+		qURL += "from=" + strconv.FormatInt(from.UnixMilli(), 10) + "&resolution=" + dtResolution(objective.Range.GetStep()) + "&"
+		aggregation = objective.Range.GetAggregation()
+	}
+	qURL += "metricSelector=" + objective.Query
 
 	d.Log.Info("Running query: " + qURL)
-	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
-	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, "GET", qURL, nil)
 	if err != nil {
 		d.Log.Error(err, "Error while creating request")
 		return "", nil, err
 	}
 
-	token, err := d.getDTApiToken(ctx, provider)
+	authorization, err := d.getAuthorization(ctx, &httpClient, provider, config, "Api-Token ")
 	if err != nil {
 		return "", nil, err
 	}
 
-	req.Header.Set("Authorization", "Api-Token "+token)
-	res, err := d.httpClient.Do(req)
+	req.Header.Set("Authorization", authorization)
+	res, err := httpClient.Do(req)
 	if err != nil {
 		d.Log.Error(err, "Error while creating request")
 		return "", nil, err
@@ -75,31 +106,66 @@ func (d *KeptnDynatraceProvider) EvaluateQuery(ctx context.Context, objective kl
 	err = json.Unmarshal(b, &result)
 	if err != nil {
 		d.Log.Error(err, "Error while parsing response")
-		return "", nil, err
+		return "", b, err
 	}
 
-	r := fmt.Sprintf("%f", d.getSingleValue(result))
-	return r, b, nil
+	value, err := d.getSingleValue(result, aggregation)
+	if err != nil {
+		return "", b, err
+	}
+	return fmt.Sprintf("%f", value), b, nil
 }
 
-func (d *KeptnDynatraceProvider) getSingleValue(result DynatraceResponse) float64 {
-	var sum float64 = 0
-	var count uint64 = 0
+// getSingleValue reduces the data points of the only series in the result to a single value,
+// series of different dimensions are not mixed
+func (d *KeptnDynatraceProvider) getSingleValue(result DynatraceResponse, aggregation string) (float64, error) {
+	var series []DynatraceData
 	for _, r := range result.Result {
-		for _, points := range r.Data {
-			for _, v := range points.Values {
-				if v != nil {
-					sum += *v
-					count++
-				}
-			}
+		series = append(series, r.Data...)
+	}
+	if len(series) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	} else if len(series) > 1 {
+		return 0, fmt.Errorf("too many values in the query result")
+	}
+
+	var values []float64
+	for _, v := range series[0].Values {
+		if v != nil {
+			values = append(values, *v)
 		}
 	}
-	if count < 1 {
-		// cannot dive by zero
-		return 0
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	}
+	return Aggregate(aggregation, values)
+}
+
+// getAuthorization returns the value of the Authorization header, API tokens are sent with the given prefix
+func (d *KeptnDynatraceProvider) getAuthorization(ctx context.Context, httpClient *http.Client, provider klcv1alpha2.KeptnEvaluationProvider, config klcv1alpha2.DynatraceProviderConfig, apiTokenPrefix string) (string, error) {
+	token, err := d.getDTApiToken(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	if config.OAuth == nil {
+		return apiTokenPrefix + token, nil
+	}
+
+	// the API token field holds the client secret
+	settings := oauthSettings{
+		clientID:     config.OAuth.ClientID,
+		clientSecret: token,
+		tokenURL:     config.OAuth.GetTokenURL(),
+		scopes:       strings.Join(config.OAuth.Scopes, " "),
+		resource:     config.OAuth.Resource,
 	}
-	return sum / float64(count)
+	tokenSource := providerTokenSources.get(types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}, httpClient, settings)
+	accessToken, err := tokenSource.Token()
+	if err != nil {
+		d.Log.Error(err, "Could not retrieve OAuth access token")
+		return "", err
+	}
+	return "Bearer " + accessToken.AccessToken, nil
 }
 
 func (d *KeptnDynatraceProvider) getDTApiToken(ctx context.Context, provider klcv1alpha2.KeptnEvaluationProvider) (string, error) {
@@ -117,3 +183,71 @@ func (d *KeptnDynatraceProvider) getDTApiToken(ctx context.Context, provider klc
 	}
 	return string(apiToken), nil
 }
+
+// dtResolution converts the step to a resolution of the metrics API, which supports minutes at the finest
+func dtResolution(step time.Duration) string {
+	minutes := int64(step.Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	return strconv.FormatInt(minutes, 10) + "m"
+}
+
+// oauthSettings are the OAuth client credentials of a provider, with the client secret read from its secret
+type oauthSettings struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	scopes       string
+	resource     string
+}
+
+type cachedTokenSource struct {
+	transport http.RoundTripper
+	settings  oauthSettings
+	source    oauth2.TokenSource
+}
+
+// tokenSourceCache is a concurrency-safe cache of the OAuth token sources of the providers. A token source is
+// replaced once the OAuth settings of its provider, its secret or its transport change.
+type tokenSourceCache struct {
+	mtx     sync.Mutex
+	sources map[types.NamespacedName]cachedTokenSource
+}
+
+func (c *tokenSourceCache) get(provider types.NamespacedName, httpClient *http.Client, settings oauthSettings) oauth2.TokenSource {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	cached, ok := c.sources[provider]
+	if ok && cached.transport == httpClient.Transport && cached.settings == settings {
+		return cached.source
+	}
+
+	credentials := clientcredentials.Config{
+		ClientID:     settings.clientID,
+		ClientSecret: settings.clientSecret,
+		TokenURL:     settings.tokenURL,
+		Scopes:       strings.Fields(settings.scopes),
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	if settings.resource != "" {
+		credentials.EndpointParams = url.Values{"resource": {settings.resource}}
+	}
+	// tokens are refreshed by whichever query needs one after the expiry, so the source is not bound to the context
+	// of the query that created it
+	client := *httpClient
+	source := credentials.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, &client))
+	c.sources[provider] = cachedTokenSource{
+		transport: httpClient.Transport,
+		settings:  settings,
+		source:    source,
+	}
+	return source
+}
+
+func (c *tokenSourceCache) evict(provider types.NamespacedName) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.sources, provider)
+}
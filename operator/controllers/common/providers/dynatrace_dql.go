@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+)
+
+const dqlRequestTimeoutMilliseconds = 5000
+
+type DynatraceDQLRequest struct {
+	Query                      string `json:"query"`
+	DefaultTimeframeStart      string `json:"defaultTimeframeStart,omitempty"`
+	DefaultTimeframeEnd        string `json:"defaultTimeframeEnd,omitempty"`
+	RequestTimeoutMilliseconds int    `json:"requestTimeoutMilliseconds"`
+}
+
+type DynatraceDQLResponse struct {
+	State        string              `json:"state"`
+	RequestToken string              `json:"requestToken,omitempty"`
+	Result       *DynatraceDQLResult `json:"result,omitempty"`
+}
+
+type DynatraceDQLResult struct {
+	Records []map[string]interface{} `json:"records"`
+}
+
+// evaluateDQL runs the DQL query on Grail and polls for the result until it is available
+func (d *KeptnDynatraceProvider) evaluateDQL(ctx context.Context, httpClient *http.Client, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider, config klcv1alpha2.DynatraceProviderConfig) (string, []byte, error) {
+	authorization, err := d.getAuthorization(ctx, httpClient, provider, config, "Bearer ")
+	if err != nil {
+		return "", nil, err
+	}
+
+	request := DynatraceDQLRequest{
+		Query:                      objective.Query,
+		RequestTimeoutMilliseconds: dqlRequestTimeoutMilliseconds,
+	}
+	aggregation := ""
+	if objective.Range != nil {
+		now := time.Now().UTC()
+		request.DefaultTimeframeStart = now.Add(-objective.Range.GetInterval()).Format(time.RFC3339)
+		request.DefaultTimeframeEnd = now.Format(time.RFC3339)
+		aggregation = objective.Range.GetAggregation()
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	d.Log.Info("Running DQL query: " + objective.Query)
+	b, result, err := d.doDQLRequest(ctx, httpClient, http.MethodPost, provider.Spec.TargetServer+"/platform/storage/query/v1/query:execute", body, authorization)
+	if err != nil {
+		return "", b, err
+	}
+	for result.State == "RUNNING" || result.State == "NOT_STARTED" {
+		pollURL := provider.Spec.TargetServer + "/platform/storage/query/v1/query:poll?request-token=" + url.QueryEscape(result.RequestToken) +
+			"&request-timeout-milliseconds=" + strconv.Itoa(dqlRequestTimeoutMilliseconds)
+		b, result, err = d.doDQLRequest(ctx, httpClient, http.MethodGet, pollURL, nil, authorization)
+		if err != nil {
+			return "", b, err
+		}
+	}
+	if result.State != "SUCCEEDED" || result.Result == nil {
+		return "", b, fmt.Errorf("DQL query finished with state %s", result.State)
+	}
+
+	value, err := getDQLValue(*result.Result, aggregation)
+	if err != nil {
+		return "", b, err
+	}
+	return fmt.Sprintf("%f", value), b, nil
+}
+
+func (d *KeptnDynatraceProvider) doDQLRequest(ctx context.Context, httpClient *http.Client, method string, qURL string, body []byte, authorization string) ([]byte, DynatraceDQLResponse, error) {
+	result := DynatraceDQLResponse{}
+	req, err := http.NewRequestWithContext(ctx, method, qURL, bytes.NewReader(body))
+	if err != nil {
+		d.Log.Error(err, "Error while creating request")
+		return nil, result, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		d.Log.Error(err, "Error while creating request")
+		return nil, result, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			d.Log.Error(err, "Could not close request body")
+		}
+	}()
+
+	// we ignore the error here because we fail later while unmarshalling
+	b, _ := io.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return b, result, fmt.Errorf("DQL query failed with status %d", res.StatusCode)
+	}
+	if err := json.Unmarshal(b, &result); err != nil {
+		d.Log.Error(err, "Error while parsing response")
+		return b, result, err
+	}
+	return b, result, nil
+}
+
+// getDQLValue reads the value field of the only record, timeseries are reduced with the given aggregation
+func getDQLValue(result DynatraceDQLResult, aggregation string) (float64, error) {
+	if len(result.Records) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	} else if len(result.Records) > 1 {
+		return 0, fmt.Errorf("too many values in the query result")
+	}
+
+	field, ok := result.Records[0]["value"]
+	if !ok {
+		return 0, fmt.Errorf("the query result has no value field")
+	}
+	switch v := field.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case []interface{}:
+		var values []float64
+		for _, point := range v {
+			if number, ok := point.(float64); ok {
+				values = append(values, number)
+			}
+		}
+		return Aggregate(aggregation, values)
+	default:
+		return 0, fmt.Errorf("value %v is not a number", v)
+	}
+}
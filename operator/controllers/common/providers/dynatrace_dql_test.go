@@ -0,0 +1,196 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const dqlRunningPayload = "{\"state\":\"RUNNING\",\"requestToken\":\"my-token\"}"
+const dqlPayload = "{\"state\":\"SUCCEEDED\",\"result\":{\"records\":[{\"value\":42.5}],\"types\":[]}}"
+const dqlTimeseriesPayload = "{\"state\":\"SUCCEEDED\",\"result\":{\"records\":[{\"value\":[10,null,30],\"timeframe\":{}}]}}"
+const dqlMultiRecordPayload = "{\"state\":\"SUCCEEDED\",\"result\":{\"records\":[{\"value\":1},{\"value\":2}]}}"
+const dqlNoValuePayload = "{\"state\":\"SUCCEEDED\",\"result\":{\"records\":[{\"count\":1}]}}"
+const dqlFailedPayload = "{\"state\":\"FAILED\"}"
+
+func newDynatraceTestProvider(secretData map[string][]byte) KeptnDynatraceProvider {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dtcreds",
+		},
+		Data: secretData,
+	}
+	return KeptnDynatraceProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(secret),
+	}
+}
+
+func newDynatraceEvaluationProvider(targetServer string, config *klcv1alpha2.DynatraceProviderConfig) klcv1alpha2.KeptnEvaluationProvider {
+	return klcv1alpha2.KeptnEvaluationProvider{
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "dtcreds",
+				},
+				Key: "token",
+			},
+			TargetServer: targetServer,
+			Dynatrace:    config,
+		},
+	}
+}
+
+func TestEvaluateQuery_DQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		execute     string
+		poll        string
+		aggregation string
+		out         string
+		wantError   bool
+	}{
+		{
+			name:    "immediate result",
+			execute: dqlPayload,
+			out:     fmt.Sprintf("%f", 42.5),
+		},
+		{
+			name:    "polled result",
+			execute: dqlRunningPayload,
+			poll:    dqlPayload,
+			out:     fmt.Sprintf("%f", 42.5),
+		},
+		{
+			name:        "timeseries",
+			execute:     dqlTimeseriesPayload,
+			aggregation: "sum",
+			out:         fmt.Sprintf("%f", 40.0),
+		},
+		{
+			name:      "multiple records",
+			execute:   dqlMultiRecordPayload,
+			wantError: true,
+		},
+		{
+			name:      "no value field",
+			execute:   dqlNoValuePayload,
+			wantError: true,
+		},
+		{
+			name:      "failed query",
+			execute:   dqlRunningPayload,
+			poll:      dqlFailedPayload,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "Bearer mytoken", r.Header.Get("Authorization"))
+				var payload string
+				switch r.URL.Path {
+				case "/platform/storage/query/v1/query:execute":
+					require.Equal(t, "POST", r.Method)
+					request := DynatraceDQLRequest{}
+					require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+					require.Equal(t, "timeseries avg(dt.host.cpu.usage)", request.Query)
+					require.NotEmpty(t, request.DefaultTimeframeStart)
+					payload = tt.execute
+				case "/platform/storage/query/v1/query:poll":
+					require.Equal(t, "GET", r.Method)
+					require.Equal(t, "my-token", r.URL.Query().Get("request-token"))
+					payload = tt.poll
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				_, err := w.Write([]byte(payload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kdp := newDynatraceTestProvider(map[string][]byte{"token": []byte("mytoken")})
+			obj := klcv1alpha2.Objective{
+				Query: "timeseries avg(dt.host.cpu.usage)",
+				Range: &klcv1alpha2.QueryRange{Aggregation: tt.aggregation},
+			}
+			p := newDynatraceEvaluationProvider(svr.URL, &klcv1alpha2.DynatraceProviderConfig{API: "dql"})
+			r, raw, e := kdp.EvaluateQuery(context.TODO(), obj, p)
+			if tt.wantError {
+				require.NotNil(t, e)
+				require.Equal(t, "", r)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, tt.out, r)
+			require.NotEmpty(t, raw)
+		})
+	}
+}
+
+func TestEvaluateQuery_OAuth(t *testing.T) {
+	tokenRequests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sso/oauth2/token":
+			tokenRequests++
+			require.Nil(t, r.ParseForm())
+			require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+			require.Equal(t, "my-client", r.Form.Get("client_id"))
+			require.Equal(t, "my-client-secret", r.Form.Get("client_secret"))
+			require.Equal(t, "storage:metrics:read", r.Form.Get("scope"))
+			require.Equal(t, "urn:dtenvironment:abc12345", r.Form.Get("resource"))
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte("{\"access_token\":\"my-access-token\",\"token_type\":\"Bearer\",\"expires_in\":300}"))
+			require.Nil(t, err)
+		case "/api/v2/metrics/query":
+			require.Equal(t, "Bearer my-access-token", r.Header.Get("Authorization"))
+			require.NotEmpty(t, r.URL.Query().Get("from"))
+			require.Equal(t, "2m", r.URL.Query().Get("resolution"))
+			_, err := w.Write([]byte(dtpayload))
+			require.Nil(t, err)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer svr.Close()
+
+	kdp := newDynatraceTestProvider(map[string][]byte{"token": []byte("my-client-secret")})
+	obj := klcv1alpha2.Objective{
+		Query: "builtin:host.cpu.usage",
+		Range: &klcv1alpha2.QueryRange{
+			Step:        metav1.Duration{Duration: 150 * time.Second},
+			Aggregation: "last",
+		},
+	}
+	p := newDynatraceEvaluationProvider(svr.URL, &klcv1alpha2.DynatraceProviderConfig{
+		OAuth: &klcv1alpha2.DynatraceOAuthConfig{
+			ClientID: "my-client",
+			TokenURL: svr.URL + "/sso/oauth2/token",
+			Scopes:   []string{"storage:metrics:read"},
+			Resource: "urn:dtenvironment:abc12345",
+		},
+	})
+	r, raw, e := kdp.EvaluateQuery(context.TODO(), obj, p)
+	require.Nil(t, e)
+	require.Equal(t, []byte(dtpayload), raw)
+	require.Equal(t, fmt.Sprintf("%f", 50.0), r)
+
+	// the access token is reused until it expires
+	_, _, e = kdp.EvaluateQuery(context.TODO(), obj, p)
+	require.Nil(t, e)
+	require.Equal(t, 1, tokenRequests)
+}
@@ -22,10 +22,13 @@ const dtpayload = "{\"totalCount\":1,\"nextPageKey\":null,\"resolution\":\"1m\",
 
 func TestGetSingleValue(t *testing.T) {
 	v := 5.0
+	w := 7.0
 	tests := []struct {
-		name   string
-		input  DynatraceResponse
-		result float64
+		name        string
+		input       DynatraceResponse
+		aggregation string
+		result      float64
+		wantError   bool
 	}{
 		{
 			name: "happy path",
@@ -42,12 +45,46 @@ func TestGetSingleValue(t *testing.T) {
 			},
 			result: v,
 		},
+		{
+			name: "reducer",
+			input: DynatraceResponse{
+				Result: []DynatraceResult{
+					{
+						Data: []DynatraceData{
+							{
+								Values: []*float64{&v, nil, &w},
+							},
+						},
+					},
+				},
+			},
+			aggregation: "max",
+			result:      w,
+		},
+		{
+			name: "multiple dimensions",
+			input: DynatraceResponse{
+				Result: []DynatraceResult{
+					{
+						Data: []DynatraceData{
+							{
+								Values: []*float64{&v},
+							},
+							{
+								Values: []*float64{&w},
+							},
+						},
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			name: "empty path",
 			input: DynatraceResponse{
 				Result: []DynatraceResult{},
 			},
-			result: 0.0,
+			wantError: true,
 		},
 		{
 			name: "no data",
@@ -58,7 +95,7 @@ func TestGetSingleValue(t *testing.T) {
 					},
 				},
 			},
-			result: 0.0,
+			wantError: true,
 		},
 		{
 			name: "no values",
@@ -73,7 +110,7 @@ func TestGetSingleValue(t *testing.T) {
 					},
 				},
 			},
-			result: 0.0,
+			wantError: true,
 		},
 		{
 			name: "nil values",
@@ -88,14 +125,19 @@ func TestGetSingleValue(t *testing.T) {
 					},
 				},
 			},
-			result: 0.0,
+			wantError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			kdp := KeptnDynatraceProvider{}
-			r := kdp.getSingleValue(tt.input)
+			r, err := kdp.getSingleValue(tt.input, tt.aggregation)
+			if tt.wantError {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
 			require.Equal(t, tt.result, r)
 		})
 
@@ -180,7 +222,7 @@ func TestEvaluateQuery_WrongPayloadHandling(t *testing.T) {
 	}
 	r, raw, e := kdp.EvaluateQuery(context.TODO(), obj, p)
 	require.Equal(t, "", r)
-	require.Equal(t, []byte(nil), raw)
+	require.Equal(t, []byte("garbage"), raw)
 	require.NotNil(t, e)
 }
 
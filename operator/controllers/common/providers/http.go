@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-logr/logr"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type KeptnHTTPProvider struct {
+	Log        logr.Logger
+	httpClient http.Client
+	k8sClient  client.Client
+}
+
+// httpTemplateData holds the values that can be used in the templates of the request
+type httpTemplateData struct {
+	Query  string
+	Secret string
+}
+
+// EvaluateQuery fetches the SLI values from a generic JSON endpoint
+func (h *KeptnHTTPProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
+	if provider.Spec.HTTP == nil {
+		return "", nil, errors.New("the http property of the provider is missing")
+	}
+	config := *provider.Spec.HTTP
+
+	ctx, cancel := context.WithTimeout(ctx, provider.GetTimeout())
+	defer cancel()
+
+	data := httpTemplateData{Query: objective.Query}
+	if provider.HasSecretDefined() {
+		secret, err := getSecretValue(ctx, h.k8sClient, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		data.Secret = secret
+	}
+
+	req, err := h.newRequest(ctx, provider.Spec.TargetServer, config, data)
+	if err != nil {
+		h.Log.Error(err, "Error while creating request")
+		return "", nil, err
+	}
+
+	httpClient, err := configureHTTPClient(ctx, h.k8sClient, h.httpClient, provider)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the resolved URL and headers may contain the secret, so only the query is logged
+	h.Log.Info("Running query: " + objective.Query)
+	res, err := httpClient.Do(req)
+	if err != nil {
+		h.Log.Error(err, "Error while creating request")
+		return "", nil, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			h.Log.Error(err, "Could not close request body")
+		}
+	}()
+
+	// we ignore the error here because we fail later while unmarshalling
+	b, _ := io.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", b, fmt.Errorf("query failed with status %d", res.StatusCode)
+	}
+	value, err := extractValue(b, config.JSONPath)
+	if err != nil {
+		h.Log.Error(err, "Error while parsing response")
+		return "", b, err
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64), b, nil
+}
+
+func (h *KeptnHTTPProvider) newRequest(ctx context.Context, urlTemplate string, config klcv1alpha2.HTTPProviderConfig, data httpTemplateData) (*http.Request, error) {
+	url, err := executeTemplate(urlTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := executeTemplate(config.Body, data)
+	if err != nil {
+		return nil, err
+	}
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, valueTemplate := range config.Headers {
+		value, err := executeTemplate(valueTemplate, data)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+func executeTemplate(text string, data httpTemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("request").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid request template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("could not resolve request template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// extractValue returns the single number the JSONPath expression matches in the given JSON document,
+// the braces around the expression are optional
+func extractValue(document []byte, expression string) (float64, error) {
+	var data interface{}
+	if err := json.Unmarshal(document, &data); err != nil {
+		return 0, err
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(expression), "{") {
+		expression = "{" + expression + "}"
+	}
+	jp := jsonpath.New("value")
+	if err := jp.Parse(expression); err != nil {
+		return 0, fmt.Errorf("invalid JSONPath expression: %w", err)
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var values []interface{}
+	for _, result := range results {
+		for _, r := range result {
+			values = append(values, r.Interface())
+		}
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no values in query result")
+	} else if len(values) > 1 {
+		return 0, fmt.Errorf("too many values in the query result")
+	}
+
+	switch v := values[0].(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("value %v is not a number", v)
+	}
+}
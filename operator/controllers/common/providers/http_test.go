@@ -0,0 +1,193 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const httpPayload = "{\"data\":{\"service\":\"podtato-head\",\"values\":[{\"name\":\"error-rate\",\"value\":0.25},{\"name\":\"availability\",\"value\":\"99.9\"}],\"total\":42}}"
+
+func TestHTTP_EvaluateQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		jsonPath  string
+		status    int
+		out       string
+		wantError bool
+	}{
+		{
+			name:     "number",
+			jsonPath: "{.data.total}",
+			status:   http.StatusOK,
+			out:      "42",
+		},
+		{
+			name:     "expression without braces",
+			jsonPath: ".data.values[0].value",
+			status:   http.StatusOK,
+			out:      "0.25",
+		},
+		{
+			name:     "number in a string",
+			jsonPath: `{.data.values[?(@.name=="availability")].value}`,
+			status:   http.StatusOK,
+			out:      "99.9",
+		},
+		{
+			name:      "not a number",
+			jsonPath:  "{.data.service}",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "too many values",
+			jsonPath:  "{.data.values[*].value}",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "no values",
+			jsonPath:  "{.data.values[5].value}",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "invalid expression",
+			jsonPath:  "{.data[",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "error status",
+			jsonPath:  "{.data.total}",
+			status:    http.StatusInternalServerError,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "POST", r.Method)
+				require.Equal(t, "/api/values", r.URL.Path)
+				require.Equal(t, "error rate", r.URL.Query().Get("name"))
+				require.Equal(t, "Bearer mytoken", r.Header.Get("Authorization"))
+				require.Equal(t, "podtato-head", r.Header.Get("X-Service"))
+				body, err := io.ReadAll(r.Body)
+				require.Nil(t, err)
+				require.Equal(t, "{\"query\": \"error rate\"}", string(body))
+				w.WriteHeader(tt.status)
+				_, err = w.Write([]byte(httpPayload))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mysecret",
+				},
+				Data: map[string][]byte{
+					"token": []byte("mytoken"),
+				},
+			}
+			khp := KeptnHTTPProvider{
+				httpClient: http.Client{},
+				Log:        ctrl.Log.WithName("testytest"),
+				k8sClient:  fake.NewClient(secret),
+			}
+			obj := klcv1alpha2.Objective{
+				Query: "error rate",
+			}
+			p := klcv1alpha2.KeptnEvaluationProvider{
+				Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+					Type:         HTTPProviderName,
+					TargetServer: svr.URL + "/api/values?name={{urlquery .Query}}",
+					SecretKeyRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "mysecret",
+						},
+						Key: "token",
+					},
+					HTTP: &klcv1alpha2.HTTPProviderConfig{
+						Method: "POST",
+						Headers: map[string]string{
+							"Authorization": "Bearer {{.Secret}}",
+							"X-Service":     "podtato-head",
+						},
+						Body:     "{\"query\": \"{{.Query}}\"}",
+						JSONPath: tt.jsonPath,
+					},
+				},
+			}
+			r, raw, e := khp.EvaluateQuery(context.TODO(), obj, p)
+			if tt.wantError {
+				require.NotNil(t, e)
+				require.Equal(t, "", r)
+				// the response is kept to find out why the query failed
+				require.Equal(t, []byte(httpPayload), raw)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, tt.out, r)
+			require.Equal(t, []byte(httpPayload), raw)
+		})
+	}
+}
+
+func TestHTTP_EvaluateQuery_Configuration(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		_, err := w.Write([]byte(httpPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	khp := KeptnHTTPProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(),
+	}
+	obj := klcv1alpha2.Objective{
+		Query: "total",
+	}
+
+	// without the http configuration there is nothing to extract the value with
+	p := klcv1alpha2.KeptnEvaluationProvider{
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			TargetServer: svr.URL,
+		},
+	}
+	_, _, e := khp.EvaluateQuery(context.TODO(), obj, p)
+	require.NotNil(t, e)
+
+	// no secret is needed if none is referenced
+	p.Spec.HTTP = &klcv1alpha2.HTTPProviderConfig{JSONPath: "{.data.total}"}
+	r, _, e := khp.EvaluateQuery(context.TODO(), obj, p)
+	require.Nil(t, e)
+	require.Equal(t, "42", r)
+
+	p.Spec.SecretKeyRef = corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "mysecret",
+		},
+		Key: "token",
+	}
+	_, _, e = khp.EvaluateQuery(context.TODO(), obj, p)
+	require.True(t, errors.IsNotFound(e))
+
+	p.Spec.SecretKeyRef = corev1.SecretKeySelector{}
+	p.Spec.HTTP.Headers = map[string]string{"Authorization": "{{.Token}}"}
+	_, _, e = khp.EvaluateQuery(context.TODO(), obj, p)
+	require.NotNil(t, e)
+}
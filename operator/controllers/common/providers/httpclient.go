@@ -0,0 +1,219 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// providerTransports keeps the configured transport of each provider, so that its connection pool is reused by the
+// queries of all evaluations instead of a new one being opened for each query
+var providerTransports = &transportCache{transports: map[types.NamespacedName]cachedTransport{}}
+
+// configureHTTPClient returns a copy of the given client that honours the TLS, proxy and basic auth settings
+// of the provider, the client is returned unchanged if none of them is set
+func configureHTTPClient(ctx context.Context, k8sClient client.Client, httpClient http.Client, provider klcv1alpha2.KeptnEvaluationProvider) (http.Client, error) {
+	if provider.Spec.TLS == nil && provider.Spec.ProxyURL == "" && provider.Spec.BasicAuth == nil {
+		return httpClient, nil
+	}
+
+	settings, err := readHTTPSettings(ctx, k8sClient, provider)
+	if err != nil {
+		return httpClient, err
+	}
+	transport, err := providerTransports.get(types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}, httpClient.Transport, settings)
+	if err != nil {
+		return httpClient, err
+	}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
+
+// httpSettings are the TLS, proxy and basic auth settings of a provider, with the values of the referenced secrets
+type httpSettings struct {
+	insecureSkipVerify bool
+	ca                 string
+	cert               string
+	key                string
+	proxyURL           string
+	username           string
+	password           string
+}
+
+func readHTTPSettings(ctx context.Context, k8sClient client.Client, provider klcv1alpha2.KeptnEvaluationProvider) (httpSettings, error) {
+	settings := httpSettings{proxyURL: provider.Spec.ProxyURL}
+	var err error
+	if spec := provider.Spec.TLS; spec != nil {
+		settings.insecureSkipVerify = spec.InsecureSkipVerify
+		if spec.CA != nil {
+			if settings.ca, err = getSecretKeyValue(ctx, k8sClient, provider.Namespace, *spec.CA); err != nil {
+				return settings, err
+			}
+		}
+		if spec.Cert != nil || spec.Key != nil {
+			if spec.Cert == nil || spec.Key == nil {
+				return settings, errors.New("both cert and key are needed for client certificates")
+			}
+			if settings.cert, err = getSecretKeyValue(ctx, k8sClient, provider.Namespace, *spec.Cert); err != nil {
+				return settings, err
+			}
+			if settings.key, err = getSecretKeyValue(ctx, k8sClient, provider.Namespace, *spec.Key); err != nil {
+				return settings, err
+			}
+		}
+	}
+	if provider.Spec.BasicAuth != nil {
+		settings.username = provider.Spec.BasicAuth.Username
+		if settings.password, err = getSecretKeyValue(ctx, k8sClient, provider.Namespace, provider.Spec.BasicAuth.Password); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
+
+type cachedTransport struct {
+	base      http.RoundTripper
+	settings  httpSettings
+	transport *http.Transport
+	client    http.RoundTripper
+}
+
+// transportCache is a concurrency-safe cache of the transports of the providers. A transport is replaced, and the
+// idle connections of the old one are closed, once the settings of its provider or their secrets change.
+type transportCache struct {
+	mtx        sync.Mutex
+	transports map[types.NamespacedName]cachedTransport
+}
+
+func (c *transportCache) get(provider types.NamespacedName, base http.RoundTripper, settings httpSettings) (http.RoundTripper, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	cached, ok := c.transports[provider]
+	if ok && cached.base == base && cached.settings == settings {
+		return cached.client, nil
+	}
+
+	transport, err := newTransport(base, settings)
+	if err != nil {
+		return nil, err
+	}
+	var roundTripper http.RoundTripper = transport
+	if settings.username != "" || settings.password != "" {
+		roundTripper = &basicAuthRoundTripper{
+			username: settings.username,
+			password: settings.password,
+			next:     transport,
+		}
+	}
+	if ok {
+		cached.transport.CloseIdleConnections()
+	}
+	c.transports[provider] = cachedTransport{
+		base:      base,
+		settings:  settings,
+		transport: transport,
+		client:    roundTripper,
+	}
+	return roundTripper, nil
+}
+
+func (c *transportCache) evict(provider types.NamespacedName) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if cached, ok := c.transports[provider]; ok {
+		cached.transport.CloseIdleConnections()
+		delete(c.transports, provider)
+	}
+}
+
+func newTransport(base http.RoundTripper, settings httpSettings) (*http.Transport, error) {
+	var transport *http.Transport
+	if t, ok := base.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	tlsConfig, err := newTLSConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if settings.proxyURL != "" {
+		proxyURL, err := url.Parse(settings.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return transport, nil
+}
+
+// newTLSConfig returns the TLS configuration of the settings, or nil if they have none
+func newTLSConfig(settings httpSettings) (*tls.Config, error) {
+	if !settings.insecureSkipVerify && settings.ca == "" && settings.cert == "" {
+		return nil, nil
+	}
+
+	//nolint:gosec // skipping the verification is an explicit choice of the user, e.g. for lab clusters
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: settings.insecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if settings.ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(settings.ca)) {
+			return nil, errors.New("the CA bundle does not contain a valid PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if settings.cert != "" {
+		certificate, err := tls.X509KeyPair([]byte(settings.cert), []byte(settings.key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// getSecretKeyValue returns the value of the given key of a secret in the namespace
+func getSecretKeyValue(ctx context.Context, k8sClient client.Client, namespace string, selector corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret); err != nil {
+		return "", err
+	}
+
+	value := secret.Data[selector.Key]
+	if len(value) == 0 {
+		return "", fmt.Errorf("secret contains invalid key %s", selector.Key)
+	}
+	return string(value), nil
+}
+
+type basicAuthRoundTripper struct {
+	username string
+	password string
+	next     http.RoundTripper
+}
+
+func (b *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request must not be modified, see http.RoundTripper
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(b.username, b.password)
+	return b.next.RoundTrip(req)
+}
@@ -0,0 +1,208 @@
+package providers
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func secretKey(name string, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: name,
+		},
+		Key: key,
+	}
+}
+
+func TestConfigureHTTPClient_TLS(t *testing.T) {
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(promPayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "certs",
+		},
+		Data: map[string][]byte{
+			"ca.crt":   ca,
+			"tls.crt":  []byte("garbage"),
+			"tls.key":  []byte("garbage"),
+			"password": []byte("secret"),
+		},
+	}
+	kpp := KeptnPrometheusProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(secret),
+	}
+	obj := klcv1alpha2.Objective{
+		Query: "my-query",
+	}
+
+	tests := []struct {
+		name      string
+		tls       *klcv1alpha2.ProviderTLSConfig
+		wantError bool
+	}{
+		{
+			name:      "unknown authority",
+			wantError: true,
+		},
+		{
+			name: "custom CA",
+			tls:  &klcv1alpha2.ProviderTLSConfig{CA: secretKey("certs", "ca.crt")},
+		},
+		{
+			name: "insecure skip verify",
+			tls:  &klcv1alpha2.ProviderTLSConfig{InsecureSkipVerify: true},
+		},
+		{
+			name:      "invalid CA",
+			tls:       &klcv1alpha2.ProviderTLSConfig{CA: secretKey("certs", "tls.crt")},
+			wantError: true,
+		},
+		{
+			name:      "missing CA",
+			tls:       &klcv1alpha2.ProviderTLSConfig{CA: secretKey("certs", "missing")},
+			wantError: true,
+		},
+		{
+			name:      "client certificate without key",
+			tls:       &klcv1alpha2.ProviderTLSConfig{InsecureSkipVerify: true, Cert: secretKey("certs", "tls.crt")},
+			wantError: true,
+		},
+		{
+			name:      "invalid client certificate",
+			tls:       &klcv1alpha2.ProviderTLSConfig{InsecureSkipVerify: true, Cert: secretKey("certs", "tls.crt"), Key: secretKey("certs", "tls.key")},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := klcv1alpha2.KeptnEvaluationProvider{
+				Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+					TargetServer: svr.URL,
+					TLS:          tt.tls,
+				},
+			}
+			r, _, e := kpp.EvaluateQuery(context.TODO(), obj, p)
+			if tt.wantError {
+				require.NotNil(t, e)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, "1", r)
+		})
+	}
+}
+
+func TestConfigureHTTPClient_ProxyAndBasicAuth(t *testing.T) {
+	target, err := url.Parse("http://prometheus.monitoring.svc.cluster.local:9090")
+	require.Nil(t, err)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// requests to a proxy have the absolute URL of the target
+		require.Equal(t, target.Host, r.URL.Host)
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "keptn", username)
+		require.Equal(t, "secret", password)
+		_, err := w.Write([]byte(promPayload))
+		require.Nil(t, err)
+	}))
+	defer proxy.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "prometheus-auth",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}
+	kpp := KeptnPrometheusProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(secret),
+	}
+	p := klcv1alpha2.KeptnEvaluationProvider{
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			TargetServer: target.String(),
+			ProxyURL:     proxy.URL,
+			BasicAuth: &klcv1alpha2.BasicAuthConfig{
+				Username: "keptn",
+				Password: *secretKey("prometheus-auth", "password"),
+			},
+		},
+	}
+	r, _, e := kpp.EvaluateQuery(context.TODO(), klcv1alpha2.Objective{Query: "my-query"}, p)
+	require.Nil(t, e)
+	require.Equal(t, "1", r)
+
+	p.Spec.BasicAuth.Password = *secretKey("prometheus-auth", "missing")
+	_, _, e = kpp.EvaluateQuery(context.TODO(), klcv1alpha2.Objective{Query: "my-query"}, p)
+	require.NotNil(t, e)
+}
+
+func TestConfigureHTTPClient_Timeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, err := w.Write([]byte(dtpayload))
+		require.Nil(t, err)
+	}))
+	defer svr.Close()
+
+	kdp := newDynatraceTestProvider(map[string][]byte{"token": []byte("mytoken")})
+	p := newDynatraceEvaluationProvider(svr.URL, nil)
+	p.Spec.Timeout = metav1.Duration{Duration: 50 * time.Millisecond}
+	_, _, e := kdp.EvaluateQuery(context.TODO(), klcv1alpha2.Objective{Query: "my-query"}, p)
+	require.ErrorIs(t, e, context.DeadlineExceeded)
+
+	p.Spec.Timeout = metav1.Duration{Duration: 5 * time.Second}
+	_, _, e = kdp.EvaluateQuery(context.TODO(), klcv1alpha2.Objective{Query: "my-query"}, p)
+	require.Nil(t, e)
+}
+
+func TestTransportCache(t *testing.T) {
+	cache := &transportCache{transports: map[types.NamespacedName]cachedTransport{}}
+	provider := types.NamespacedName{Namespace: "default", Name: "my-provider"}
+	settings := httpSettings{proxyURL: "http://proxy:3128", username: "user", password: "secret"}
+
+	first, err := cache.get(provider, nil, settings)
+	require.Nil(t, err)
+	second, err := cache.get(provider, nil, settings)
+	require.Nil(t, err)
+	// the connection pool of the provider is reused by all queries
+	require.Same(t, first, second)
+
+	settings.password = "rotated"
+	third, err := cache.get(provider, nil, settings)
+	require.Nil(t, err)
+	require.NotSame(t, first, third)
+	require.Len(t, cache.transports, 1)
+
+	other, err := cache.get(types.NamespacedName{Namespace: "default", Name: "other-provider"}, nil, settings)
+	require.Nil(t, err)
+	require.NotSame(t, third, other)
+	require.Len(t, cache.transports, 2)
+
+	// the transport of a deleted provider is dropped
+	cache.evict(provider)
+	require.Len(t, cache.transports, 1)
+	require.NotContains(t, cache.transports, provider)
+}
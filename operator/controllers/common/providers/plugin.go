@@ -0,0 +1,202 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/go-logr/logr"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	pluginv1 "github.com/keptn/lifecycle-toolkit/operator/controllers/common/providers/plugin/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// providerConns keeps the gRPC connection of each plugin provider, so that it is reused by the queries of all
+// evaluations instead of a new one being opened for each query
+var providerConns = &connCache{conns: map[types.NamespacedName]cachedConn{}}
+
+// KeptnPluginProvider calls out-of-process providers that implement the SLIProvider contract
+// defined in plugin/v1/provider.proto, either via gRPC or as JSON via HTTP
+type KeptnPluginProvider struct {
+	Log        logr.Logger
+	httpClient http.Client
+	k8sClient  client.Client
+}
+
+// EvaluateQuery fetches the SLI values from a plugin provider
+func (p *KeptnPluginProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, provider.GetTimeout())
+	defer cancel()
+
+	token := ""
+	if provider.HasSecretDefined() {
+		secret, err := getSecretValue(ctx, p.k8sClient, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		token = secret
+	}
+
+	config := klcv1alpha2.PluginProviderConfig{}
+	if provider.Spec.Plugin != nil {
+		config = *provider.Spec.Plugin
+	}
+
+	request := newEvaluateQueryRequest(objective, provider)
+	p.Log.Info("Running query: " + objective.Query + " with plugin " + provider.Spec.TargetServer)
+	var response *pluginv1.EvaluateQueryResponse
+	var err error
+	switch config.GetProtocol() {
+	case "grpc":
+		response, err = p.evaluateGRPC(ctx, provider, token, request)
+	case "http":
+		response, err = p.evaluateHTTP(ctx, provider, token, request)
+	default:
+		err = fmt.Errorf("plugin protocol %s not supported", config.Protocol)
+	}
+	if err != nil {
+		p.Log.Error(err, "Error while calling plugin")
+		return "", nil, err
+	}
+
+	if _, err := strconv.ParseFloat(response.Value, 64); err != nil {
+		return "", response.Raw, fmt.Errorf("plugin returned invalid value %q: %w", response.Value, err)
+	}
+	return response.Value, response.Raw, nil
+}
+
+func newEvaluateQueryRequest(objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) *pluginv1.EvaluateQueryRequest {
+	request := &pluginv1.EvaluateQueryRequest{
+		Name:              objective.Name,
+		Query:             objective.Query,
+		ProviderName:      provider.Name,
+		ProviderNamespace: provider.Namespace,
+	}
+	if objective.Range != nil {
+		request.Range = &pluginv1.QueryRange{
+			IntervalSeconds: int64(objective.Range.GetInterval().Seconds()),
+			StepSeconds:     int64(objective.Range.GetStep().Seconds()),
+			Aggregation:     objective.Range.GetAggregation(),
+		}
+	}
+	return request
+}
+
+func (p *KeptnPluginProvider) evaluateGRPC(ctx context.Context, provider klcv1alpha2.KeptnEvaluationProvider, token string, request *pluginv1.EvaluateQueryRequest) (*pluginv1.EvaluateQueryResponse, error) {
+	settings, err := readHTTPSettings(ctx, p.k8sClient, provider)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := providerConns.get(types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}, provider.Spec.TargetServer, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	return pluginv1.NewSLIProviderClient(conn).EvaluateQuery(ctx, request)
+}
+
+func (p *KeptnPluginProvider) evaluateHTTP(ctx context.Context, provider klcv1alpha2.KeptnEvaluationProvider, token string, request *pluginv1.EvaluateQueryRequest) (*pluginv1.EvaluateQueryResponse, error) {
+	httpClient, err := configureHTTPClient(ctx, p.k8sClient, p.httpClient, provider)
+	if err != nil {
+		return nil, err
+	}
+	body, err := protojson.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.Spec.TargetServer, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			p.Log.Error(err, "Could not close request body")
+		}
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("plugin failed with status %d", res.StatusCode)
+	}
+	// we ignore the error here because we fail later while unmarshalling
+	b, _ := io.ReadAll(res.Body)
+	response := &pluginv1.EvaluateQueryResponse{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+type cachedConn struct {
+	target   string
+	settings httpSettings
+	conn     *grpc.ClientConn
+}
+
+// connCache is a concurrency-safe cache of the gRPC connections of the plugin providers. A connection is replaced,
+// and the old one is closed, once the target or the settings of its provider or their secrets change.
+type connCache struct {
+	mtx   sync.Mutex
+	conns map[types.NamespacedName]cachedConn
+}
+
+func (c *connCache) get(provider types.NamespacedName, target string, settings httpSettings) (*grpc.ClientConn, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	cached, ok := c.conns[provider]
+	if ok && cached.target == target && cached.settings == settings {
+		return cached.conn, nil
+	}
+
+	tlsConfig, err := newTLSConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	transportCredentials := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+	// the connection is established in the background and outlives the query, so it is not bound to its context
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		_ = cached.conn.Close()
+	}
+	c.conns[provider] = cachedConn{target: target, settings: settings, conn: conn}
+	return conn, nil
+}
+
+func (c *connCache) evict(provider types.NamespacedName) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if cached, ok := c.conns[provider]; ok {
+		_ = cached.conn.Close()
+		delete(c.conns, provider)
+	}
+}
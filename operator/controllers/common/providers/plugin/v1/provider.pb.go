@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: controllers/common/providers/plugin/v1/provider.proto
+
+package pluginv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EvaluateQueryRequest describes the query of an objective or metric
+type EvaluateQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the objective or metric
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// query of the objective or metric, placeholders are already resolved
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// name of the KeptnEvaluationProvider
+	ProviderName string `protobuf:"bytes,3,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	// namespace of the KeptnEvaluationProvider
+	ProviderNamespace string `protobuf:"bytes,4,opt,name=provider_namespace,json=providerNamespace,proto3" json:"provider_namespace,omitempty"`
+	// range is only set if the objective defines a time window
+	Range *QueryRange `protobuf:"bytes,5,opt,name=range,proto3" json:"range,omitempty"`
+}
+
+func (x *EvaluateQueryRequest) Reset() {
+	*x = EvaluateQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateQueryRequest) ProtoMessage() {}
+
+func (x *EvaluateQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateQueryRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateQueryRequest) Descriptor() ([]byte, []int) {
+	return file_controllers_common_providers_plugin_v1_provider_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvaluateQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EvaluateQueryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *EvaluateQueryRequest) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *EvaluateQueryRequest) GetProviderNamespace() string {
+	if x != nil {
+		return x.ProviderNamespace
+	}
+	return ""
+}
+
+func (x *EvaluateQueryRequest) GetRange() *QueryRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+// QueryRange is the time window, ending now, that the query is evaluated over
+type QueryRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IntervalSeconds int64 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	StepSeconds     int64 `protobuf:"varint,2,opt,name=step_seconds,json=stepSeconds,proto3" json:"step_seconds,omitempty"`
+	// aggregation reduces the data points to a single value, e.g. avg or p95
+	Aggregation string `protobuf:"bytes,3,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+}
+
+func (x *QueryRange) Reset() {
+	*x = QueryRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRange) ProtoMessage() {}
+
+func (x *QueryRange) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRange.ProtoReflect.Descriptor instead.
+func (*QueryRange) Descriptor() ([]byte, []int) {
+	return file_controllers_common_providers_plugin_v1_provider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueryRange) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *QueryRange) GetStepSeconds() int64 {
+	if x != nil {
+		return x.StepSeconds
+	}
+	return 0
+}
+
+func (x *QueryRange) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
+}
+
+// EvaluateQueryResponse holds the result of the query
+type EvaluateQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value must be a number, e.g. "0.25"
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// raw is the unprocessed result, it is stored in the status of KeptnMetrics
+	Raw []byte `protobuf:"bytes,2,opt,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *EvaluateQueryResponse) Reset() {
+	*x = EvaluateQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateQueryResponse) ProtoMessage() {}
+
+func (x *EvaluateQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateQueryResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateQueryResponse) Descriptor() ([]byte, []int) {
+	return file_controllers_common_providers_plugin_v1_provider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EvaluateQueryResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *EvaluateQueryResponse) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+var File_controllers_common_providers_plugin_v1_provider_proto protoreflect.FileDescriptor
+
+var file_controllers_common_providers_plugin_v1_provider_proto_rawDesc = []byte{
+	0x0a, 0x35, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x2f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6b, 0x65, 0x70, 0x74, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0xc9, 0x01, 0x0a, 0x14, 0x45,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x33, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6b, 0x65, 0x70, 0x74, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x7c, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x65, 0x70, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x15, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x72, 0x61, 0x77, 0x32, 0x71, 0x0a, 0x0b, 0x53, 0x4c, 0x49, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x12, 0x62, 0x0a, 0x0d, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x2e, 0x6b, 0x65, 0x70, 0x74, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x6b, 0x65, 0x70, 0x74, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x65, 0x70, 0x74, 0x6e, 0x2f, 0x6c, 0x69, 0x66,
+	0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x6b, 0x69, 0x74, 0x2f, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x73, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x3b, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controllers_common_providers_plugin_v1_provider_proto_rawDescOnce sync.Once
+	file_controllers_common_providers_plugin_v1_provider_proto_rawDescData = file_controllers_common_providers_plugin_v1_provider_proto_rawDesc
+)
+
+func file_controllers_common_providers_plugin_v1_provider_proto_rawDescGZIP() []byte {
+	file_controllers_common_providers_plugin_v1_provider_proto_rawDescOnce.Do(func() {
+		file_controllers_common_providers_plugin_v1_provider_proto_rawDescData = protoimpl.X.CompressGZIP(file_controllers_common_providers_plugin_v1_provider_proto_rawDescData)
+	})
+	return file_controllers_common_providers_plugin_v1_provider_proto_rawDescData
+}
+
+var file_controllers_common_providers_plugin_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_controllers_common_providers_plugin_v1_provider_proto_goTypes = []interface{}{
+	(*EvaluateQueryRequest)(nil),  // 0: keptn.provider.v1.EvaluateQueryRequest
+	(*QueryRange)(nil),            // 1: keptn.provider.v1.QueryRange
+	(*EvaluateQueryResponse)(nil), // 2: keptn.provider.v1.EvaluateQueryResponse
+}
+var file_controllers_common_providers_plugin_v1_provider_proto_depIdxs = []int32{
+	1, // 0: keptn.provider.v1.EvaluateQueryRequest.range:type_name -> keptn.provider.v1.QueryRange
+	0, // 1: keptn.provider.v1.SLIProvider.EvaluateQuery:input_type -> keptn.provider.v1.EvaluateQueryRequest
+	2, // 2: keptn.provider.v1.SLIProvider.EvaluateQuery:output_type -> keptn.provider.v1.EvaluateQueryResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_controllers_common_providers_plugin_v1_provider_proto_init() }
+func file_controllers_common_providers_plugin_v1_provider_proto_init() {
+	if File_controllers_common_providers_plugin_v1_provider_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_common_providers_plugin_v1_provider_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controllers_common_providers_plugin_v1_provider_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_controllers_common_providers_plugin_v1_provider_proto_goTypes,
+		DependencyIndexes: file_controllers_common_providers_plugin_v1_provider_proto_depIdxs,
+		MessageInfos:      file_controllers_common_providers_plugin_v1_provider_proto_msgTypes,
+	}.Build()
+	File_controllers_common_providers_plugin_v1_provider_proto = out.File
+	file_controllers_common_providers_plugin_v1_provider_proto_rawDesc = nil
+	file_controllers_common_providers_plugin_v1_provider_proto_goTypes = nil
+	file_controllers_common_providers_plugin_v1_provider_proto_depIdxs = nil
+}
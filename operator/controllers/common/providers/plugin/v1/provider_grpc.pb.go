@@ -0,0 +1,107 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: controllers/common/providers/plugin/v1/provider.proto
+
+package pluginv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SLIProviderClient is the client API for SLIProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SLIProviderClient interface {
+	// EvaluateQuery fetches the value of the given query
+	EvaluateQuery(ctx context.Context, in *EvaluateQueryRequest, opts ...grpc.CallOption) (*EvaluateQueryResponse, error)
+}
+
+type sLIProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSLIProviderClient(cc grpc.ClientConnInterface) SLIProviderClient {
+	return &sLIProviderClient{cc}
+}
+
+func (c *sLIProviderClient) EvaluateQuery(ctx context.Context, in *EvaluateQueryRequest, opts ...grpc.CallOption) (*EvaluateQueryResponse, error) {
+	out := new(EvaluateQueryResponse)
+	err := c.cc.Invoke(ctx, "/keptn.provider.v1.SLIProvider/EvaluateQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SLIProviderServer is the server API for SLIProvider service.
+// All implementations must embed UnimplementedSLIProviderServer
+// for forward compatibility
+type SLIProviderServer interface {
+	// EvaluateQuery fetches the value of the given query
+	EvaluateQuery(context.Context, *EvaluateQueryRequest) (*EvaluateQueryResponse, error)
+	mustEmbedUnimplementedSLIProviderServer()
+}
+
+// UnimplementedSLIProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedSLIProviderServer struct {
+}
+
+func (UnimplementedSLIProviderServer) EvaluateQuery(context.Context, *EvaluateQueryRequest) (*EvaluateQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateQuery not implemented")
+}
+func (UnimplementedSLIProviderServer) mustEmbedUnimplementedSLIProviderServer() {}
+
+// UnsafeSLIProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SLIProviderServer will
+// result in compilation errors.
+type UnsafeSLIProviderServer interface {
+	mustEmbedUnimplementedSLIProviderServer()
+}
+
+func RegisterSLIProviderServer(s grpc.ServiceRegistrar, srv SLIProviderServer) {
+	s.RegisterService(&SLIProvider_ServiceDesc, srv)
+}
+
+func _SLIProvider_EvaluateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLIProviderServer).EvaluateQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/keptn.provider.v1.SLIProvider/EvaluateQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLIProviderServer).EvaluateQuery(ctx, req.(*EvaluateQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SLIProvider_ServiceDesc is the grpc.ServiceDesc for SLIProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SLIProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "keptn.provider.v1.SLIProvider",
+	HandlerType: (*SLIProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EvaluateQuery",
+			Handler:    _SLIProvider_EvaluateQuery_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controllers/common/providers/plugin/v1/provider.proto",
+}
@@ -0,0 +1,231 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	pluginv1 "github.com/keptn/lifecycle-toolkit/operator/controllers/common/providers/plugin/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type fakePlugin struct {
+	pluginv1.UnimplementedSLIProviderServer
+	t     *testing.T
+	value string
+	err   error
+}
+
+func (f *fakePlugin) EvaluateQuery(ctx context.Context, in *pluginv1.EvaluateQueryRequest) (*pluginv1.EvaluateQueryResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	require.True(f.t, ok)
+	require.Equal(f.t, []string{"Bearer mytoken"}, md.Get("authorization"))
+	require.Equal(f.t, "my-objective", in.Name)
+	require.Equal(f.t, "myquery", in.Query)
+	require.Equal(f.t, "my-plugin", in.ProviderName)
+	require.Equal(f.t, int64(600), in.Range.IntervalSeconds)
+	require.Equal(f.t, "p95", in.Range.Aggregation)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pluginv1.EvaluateQueryResponse{Value: f.value, Raw: []byte("raw")}, nil
+}
+
+func newPluginTestProvider() KeptnPluginProvider {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token": []byte("mytoken"),
+		},
+	}
+	return KeptnPluginProvider{
+		httpClient: http.Client{},
+		Log:        ctrl.Log.WithName("testytest"),
+		k8sClient:  fake.NewClient(secret),
+	}
+}
+
+func newPluginEvaluationProvider(targetServer string, protocol string) klcv1alpha2.KeptnEvaluationProvider {
+	return klcv1alpha2.KeptnEvaluationProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-plugin",
+		},
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			Type:         PluginProviderName,
+			TargetServer: targetServer,
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "mysecret",
+				},
+				Key: "token",
+			},
+			Plugin: &klcv1alpha2.PluginProviderConfig{
+				Protocol: protocol,
+			},
+		},
+	}
+}
+
+var pluginObjective = klcv1alpha2.Objective{
+	Name:  "my-objective",
+	Query: "myquery",
+	Range: &klcv1alpha2.QueryRange{
+		Interval:    metav1.Duration{Duration: 10 * time.Minute},
+		Aggregation: "p95",
+	},
+}
+
+func TestPlugin_EvaluateQuery_GRPC(t *testing.T) {
+	tests := []struct {
+		name      string
+		plugin    *fakePlugin
+		out       string
+		wantError bool
+	}{
+		{
+			name:   "happy path",
+			plugin: &fakePlugin{t: t, value: "0.25"},
+			out:    "0.25",
+		},
+		{
+			name:      "invalid value",
+			plugin:    &fakePlugin{t: t, value: "fast"},
+			wantError: true,
+		},
+		{
+			name:      "plugin error",
+			plugin:    &fakePlugin{t: t, err: status.Error(codes.InvalidArgument, "bad query")},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			require.Nil(t, err)
+			server := grpc.NewServer()
+			pluginv1.RegisterSLIProviderServer(server, tt.plugin)
+			go func() {
+				_ = server.Serve(lis)
+			}()
+			defer server.Stop()
+
+			kpp := newPluginTestProvider()
+			r, raw, e := kpp.EvaluateQuery(context.TODO(), pluginObjective, newPluginEvaluationProvider(lis.Addr().String(), ""))
+			if tt.wantError {
+				require.NotNil(t, e)
+				require.Equal(t, "", r)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, tt.out, r)
+			require.Equal(t, []byte("raw"), raw)
+		})
+	}
+}
+
+func TestConnCache(t *testing.T) {
+	conns := &connCache{conns: map[types.NamespacedName]cachedConn{}}
+	provider := types.NamespacedName{Namespace: "default", Name: "my-plugin"}
+
+	conn, err := conns.get(provider, "127.0.0.1:50051", httpSettings{})
+	require.Nil(t, err)
+	same, err := conns.get(provider, "127.0.0.1:50051", httpSettings{})
+	require.Nil(t, err)
+	require.Same(t, conn, same)
+
+	// the connection is replaced and closed once the provider changes
+	changed, err := conns.get(provider, "127.0.0.1:50052", httpSettings{})
+	require.Nil(t, err)
+	require.NotSame(t, conn, changed)
+	require.Equal(t, connectivity.Shutdown, conn.GetState())
+
+	_, err = conns.get(provider, "127.0.0.1:50052", httpSettings{ca: "invalid"})
+	require.NotNil(t, err)
+	require.NotEqual(t, connectivity.Shutdown, changed.GetState())
+
+	// the connection of a deleted provider is closed
+	conns.evict(provider)
+	require.Empty(t, conns.conns)
+	require.Equal(t, connectivity.Shutdown, changed.GetState())
+}
+
+func TestPlugin_EvaluateQuery_HTTP(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		status    int
+		out       string
+		wantError bool
+	}{
+		{
+			name:   "happy path",
+			in:     "{\"value\":\"42\",\"raw\":\"cmF3\",\"unknown\":true}",
+			status: http.StatusOK,
+			out:    "42",
+		},
+		{
+			name:      "invalid value",
+			in:        "{\"value\":\"\"}",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "wrong payload",
+			in:        "garbage",
+			status:    http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:      "error status",
+			in:        "{\"value\":\"42\"}",
+			status:    http.StatusBadGateway,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "POST", r.Method)
+				require.Equal(t, "/evaluate", r.URL.Path)
+				require.Equal(t, "Bearer mytoken", r.Header.Get("Authorization"))
+				body, err := io.ReadAll(r.Body)
+				require.Nil(t, err)
+				require.JSONEq(t, "{\"name\":\"my-objective\",\"query\":\"myquery\",\"providerName\":\"my-plugin\",\"range\":{\"intervalSeconds\":\"600\",\"stepSeconds\":\"15\",\"aggregation\":\"p95\"}}", string(body))
+				w.WriteHeader(tt.status)
+				_, err = w.Write([]byte(tt.in))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kpp := newPluginTestProvider()
+			r, raw, e := kpp.EvaluateQuery(context.TODO(), pluginObjective, newPluginEvaluationProvider(svr.URL+"/evaluate", "http"))
+			if tt.wantError {
+				require.NotNil(t, e)
+				require.Equal(t, "", r)
+				return
+			}
+			require.Nil(t, e)
+			require.Equal(t, tt.out, r)
+			require.Equal(t, []byte("raw"), raw)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"      //nolint:gci
 	"net/http" //nolint:gci
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -11,25 +13,36 @@ import (
 	promapi "github.com/prometheus/client_golang/api"
 	prometheus "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type KeptnPrometheusProvider struct {
 	Log        logr.Logger
 	httpClient http.Client
+	k8sClient  client.Client
 }
 
 // EvaluateQuery fetches the SLI values from prometheus provider
 func (r *KeptnPrometheusProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
-	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, provider.GetTimeout())
 	defer cancel()
 
-	queryTime := time.Now().UTC()
-	r.Log.Info("Running query: /api/v1/query?query=" + objective.Query + "&time=" + queryTime.String())
-	client, err := promapi.NewClient(promapi.Config{Address: provider.Spec.TargetServer, Client: &r.httpClient})
+	httpClient, err := configureHTTPClient(ctx, r.k8sClient, r.httpClient, provider)
+	if err != nil {
+		return "", nil, err
+	}
+	promClient, err := promapi.NewClient(promapi.Config{Address: provider.Spec.TargetServer, Client: &httpClient})
 	if err != nil {
 		return "", nil, err
 	}
-	api := prometheus.NewAPI(client)
+	api := prometheus.NewAPI(promClient)
+
+	queryTime := time.Now().UTC()
+	if objective.Range != nil {
+		return r.evaluateRangeQuery(ctx, api, objective.Query, *objective.Range, queryTime)
+	}
+
+	r.Log.Info("Running query: /api/v1/query?query=" + objective.Query + "&time=" + queryTime.String())
 	result, w, err := api.Query(
 		ctx,
 		objective.Query,
@@ -48,17 +61,17 @@ func (r *KeptnPrometheusProvider) EvaluateQuery(ctx context.Context, objective k
 	// check if we can cast the result to a vector, it might be another data struct which we can't process
 	resultVector, ok := result.(model.Vector)
 	if !ok {
-		return "", nil, fmt.Errorf("could not cast result")
+		return "", rawResult(result), fmt.Errorf("could not cast result")
 	}
 
 	// We are only allowed to return one value, if not the query may be malformed
 	// we are using two different errors to give the user more information about the result
 	if len(resultVector) == 0 {
 		r.Log.Info("No values in query result")
-		return "", nil, fmt.Errorf("no values in query result")
+		return "", rawResult(result), fmt.Errorf("no values in query result")
 	} else if len(resultVector) > 1 {
 		r.Log.Info("Too many values in the query result")
-		return "", nil, fmt.Errorf("too many values in the query result")
+		return "", rawResult(result), fmt.Errorf("too many values in the query result")
 	}
 	value := resultVector[0].Value.String()
 	b, err := resultVector[0].Value.MarshalJSON()
@@ -67,3 +80,65 @@ func (r *KeptnPrometheusProvider) EvaluateQuery(ctx context.Context, objective k
 	}
 	return value, b, nil
 }
+
+// evaluateRangeQuery runs the query over the time window that ends at queryTime and aggregates the data points
+func (r *KeptnPrometheusProvider) evaluateRangeQuery(ctx context.Context, api prometheus.API, query string, queryRange klcv1alpha2.QueryRange, queryTime time.Time) (string, []byte, error) {
+	timeRange := prometheus.Range{
+		Start: queryTime.Add(-queryRange.GetInterval()),
+		End:   queryTime,
+		Step:  queryRange.GetStep(),
+	}
+	r.Log.Info("Running query: /api/v1/query_range?query=" + query + "&start=" + timeRange.Start.String() + "&end=" + timeRange.End.String() + "&step=" + timeRange.Step.String())
+	result, w, err := api.QueryRange(
+		ctx,
+		query,
+		timeRange,
+		[]prometheus.Option{}...,
+	)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(w) != 0 {
+		r.Log.Info("Prometheus API returned warnings: " + w[0])
+	}
+
+	resultMatrix, ok := result.(model.Matrix)
+	if !ok {
+		return "", rawResult(result), fmt.Errorf("could not cast result")
+	}
+
+	// as for instant queries, the query must return a single time series
+	if len(resultMatrix) == 0 || len(resultMatrix[0].Values) == 0 {
+		r.Log.Info("No values in query result")
+		return "", rawResult(result), fmt.Errorf("no values in query result")
+	} else if len(resultMatrix) > 1 {
+		r.Log.Info("Too many values in the query result")
+		return "", rawResult(result), fmt.Errorf("too many values in the query result")
+	}
+
+	values := make([]float64, 0, len(resultMatrix[0].Values))
+	for _, point := range resultMatrix[0].Values {
+		values = append(values, float64(point.Value))
+	}
+	b, err := json.Marshal(resultMatrix[0].Values)
+	if err != nil {
+		return "", nil, err
+	}
+	value, err := Aggregate(queryRange.GetAggregation(), values)
+	if err != nil {
+		return "", b, err
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64), b, nil
+}
+
+// rawResult returns the JSON of a query result that could not be reduced to a single value, so that it can be
+// inspected, the result is parsed by the client and the response body is not available
+func rawResult(result model.Value) []byte {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil
+	}
+	return b
+}
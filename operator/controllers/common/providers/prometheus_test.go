@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -18,6 +19,10 @@ const promEmptyDataPayload = "{\"status\":\"success\",\"data\":{\"resultType\":\
 const promMatrixPayload = "{\"status\":\"success\",\"data\":{\"resultType\":\"matrix\",\"result\":[]}}"
 const promMultiPointPayload = "{\"status\":\"success\",\"data\":{\"resultType\":\"vector\",\"result\":[{\"metric\":{\"__name__\":\"kube_pod_info\",\"container\":\"kube-rbac-proxy-main\",\"created_by_kind\":\"DaemonSet\",\"created_by_name\":\"kindnet\",\"host_ip\":\"172.18.0.2\",\"host_network\":\"true\",\"instance\":\"10.244.0.24:8443\",\"job\":\"kube-state-metrics\",\"namespace\":\"kube-system\",\"node\":\"kind-control-plane\",\"pod\":\"kindnet-llt85\",\"pod_ip\":\"172.18.0.2\",\"uid\":\"0bb9d9db-2658-439f-aed9-ab3e8502397d\"},\"value\":[1669714193.275,\"1\"]},{\"metric\":{\"__name__\":\"kube_pod_info\",\"container\":\"kube-rbac-proxy-main\",\"created_by_kind\":\"DaemonSet\",\"created_by_name\":\"kube-proxy\",\"host_ip\":\"172.18.0.2\",\"host_network\":\"true\",\"instance\":\"10.244.0.24:8443\",\"job\":\"kube-state-metrics\",\"namespace\":\"kube-system\",\"node\":\"kind-control-plane\",\"pod\":\"kube-proxy-dlq7m\",\"pod_ip\":\"172.18.0.2\",\"priority_class\":\"system-node-critical\",\"uid\":\"31240e57-5286-4bc6-ad69-80b68bf806d0\"},\"value\":[1669714193.275,\"1\"]},{\"metric\":{\"__name__\":\"kube_pod_info\",\"container\":\"kube-rbac-proxy-main\",\"created_by_kind\":\"DaemonSet\",\"created_by_name\":\"node-exporter\",\"host_ip\":\"172.18.0.2\",\"host_network\":\"true\",\"instance\":\"10.244.0.24:8443\",\"job\":\"kube-state-metrics\",\"namespace\":\"monitoring\",\"node\":\"kind-control-plane\",\"pod\":\"node-exporter-dv6nr\",\"pod_ip\":\"172.18.0.2\",\"priority_class\":\"system-cluster-critical\",\"uid\":\"cf7baf10-ac9a-4b7d-9510-a6502d7ed271\"},\"value\":[1669714193.275,\"1\"]}]}}"
 
+const promRangePayload = "{\"status\":\"success\",\"data\":{\"resultType\":\"matrix\",\"result\":[{\"metric\":{\"__name__\":\"http_requests_total\",\"job\":\"podtato-head\"},\"values\":[[1669714193.275,\"1\"],[1669714208.275,\"2\"],[1669714223.275,\"6\"]]}]}}"
+const promRangeEmptyPayload = "{\"status\":\"success\",\"data\":{\"resultType\":\"matrix\",\"result\":[]}}"
+const promRangeMultiSeriesPayload = "{\"status\":\"success\",\"data\":{\"resultType\":\"matrix\",\"result\":[{\"metric\":{\"job\":\"a\"},\"values\":[[1669714193.275,\"1\"]]},{\"metric\":{\"job\":\"b\"},\"values\":[[1669714193.275,\"2\"]]}]}}"
+
 func Test_prometheus(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -43,18 +48,21 @@ func Test_prometheus(t *testing.T) {
 			name:      "multiple datapoint",
 			in:        promMultiPointPayload,
 			out:       "",
+			outraw:    promResult(promMultiPointPayload),
 			wantError: true,
 		},
 		{
 			name:      "empty datapoint",
 			in:        promEmptyDataPayload,
 			out:       "",
+			outraw:    []byte("[]"),
 			wantError: true,
 		},
 		{
 			name:      "unsupported answer type",
 			in:        promMatrixPayload,
 			out:       "",
+			outraw:    []byte("[]"),
 			wantError: true,
 		},
 		{
@@ -103,3 +111,100 @@ func Test_prometheus(t *testing.T) {
 
 	}
 }
+
+func Test_prometheusRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		aggregation string
+		out         string
+		outraw      []byte
+		wantError   bool
+	}{
+		{
+			name:        "average",
+			in:          promRangePayload,
+			aggregation: "avg",
+			out:         "3",
+			outraw:      []byte("[[1669714193.275,\"1\"],[1669714208.275,\"2\"],[1669714223.275,\"6\"]]"),
+		},
+		{
+			name:        "max",
+			in:          promRangePayload,
+			aggregation: "max",
+			out:         "6",
+			outraw:      []byte("[[1669714193.275,\"1\"],[1669714208.275,\"2\"],[1669714223.275,\"6\"]]"),
+		},
+		{
+			name:        "last",
+			in:          promRangePayload,
+			aggregation: "last",
+			out:         "6",
+			outraw:      []byte("[[1669714193.275,\"1\"],[1669714208.275,\"2\"],[1669714223.275,\"6\"]]"),
+		},
+		{
+			name:      "empty series",
+			in:        promRangeEmptyPayload,
+			outraw:    []byte("[]"),
+			wantError: true,
+		},
+		{
+			name:      "multiple series",
+			in:        promRangeMultiSeriesPayload,
+			outraw:    promResult(promRangeMultiSeriesPayload),
+			wantError: true,
+		},
+		{
+			name:      "unsupported answer type",
+			in:        promPayload,
+			outraw:    promResult(promPayload),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v1/query_range", r.URL.Path)
+				_, err := w.Write([]byte(tt.in))
+				require.Nil(t, err)
+			}))
+			defer svr.Close()
+
+			kpp := KeptnPrometheusProvider{
+				httpClient: http.Client{},
+				Log:        ctrl.Log.WithName("testytest"),
+			}
+			obj := klcv1alpha2.Objective{
+				Query: "rate(http_requests_total[1m])",
+				Range: &klcv1alpha2.QueryRange{
+					Aggregation: tt.aggregation,
+				},
+			}
+			p := klcv1alpha2.KeptnEvaluationProvider{
+				Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+					TargetServer: svr.URL,
+				},
+			}
+			r, raw, e := kpp.EvaluateQuery(context.TODO(), obj, p)
+			require.Equal(t, tt.out, r)
+			require.Equal(t, tt.outraw, raw)
+			if tt.wantError != (e != nil) {
+				t.Errorf("want error: %t, got: %v", tt.wantError, e)
+			}
+		})
+	}
+}
+
+// promResult returns the result of a query response, which is kept as raw value if it is not a single value
+func promResult(payload string) []byte {
+	response := struct {
+		Data struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		panic(err)
+	}
+	return response.Data.Result
+}
@@ -11,7 +11,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// KeptnSLIProvider is the interface that describes the operations that an SLI provider must implement
+// KeptnSLIProvider is the interface that describes the operations that an SLI provider must implement.
+// EvaluateQuery returns the value and the raw response of the provider, the raw response is returned together
+// with the error as well if the provider responded, e.g. with a result that does not contain a single value.
 type KeptnSLIProvider interface {
 	EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error)
 }
@@ -23,6 +25,7 @@ func NewProvider(provider string, log logr.Logger, k8sClient client.Client) (Kep
 		return &KeptnPrometheusProvider{
 			httpClient: http.Client{},
 			Log:        log,
+			k8sClient:  k8sClient,
 		}, nil
 	case DynatraceProviderName:
 		return &KeptnDynatraceProvider{
@@ -30,6 +33,24 @@ func NewProvider(provider string, log logr.Logger, k8sClient client.Client) (Kep
 			Log:        log,
 			k8sClient:  k8sClient,
 		}, nil
+	case DatadogProviderName:
+		return &KeptnDatadogProvider{
+			httpClient: http.Client{},
+			Log:        log,
+			k8sClient:  k8sClient,
+		}, nil
+	case HTTPProviderName:
+		return &KeptnHTTPProvider{
+			httpClient: http.Client{},
+			Log:        log,
+			k8sClient:  k8sClient,
+		}, nil
+	case PluginProviderName:
+		return &KeptnPluginProvider{
+			httpClient: http.Client{},
+			Log:        log,
+			k8sClient:  k8sClient,
+		}, nil
 	case KeptnMetricProviderName:
 		return &KeptnMetricProvider{
 			Log:       log,
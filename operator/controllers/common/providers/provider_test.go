@@ -23,6 +23,21 @@ func TestFactory(t *testing.T) {
 			provider: &KeptnDynatraceProvider{},
 			err:      false,
 		},
+		{
+			name:     DatadogProviderName,
+			provider: &KeptnDatadogProvider{},
+			err:      false,
+		},
+		{
+			name:     HTTPProviderName,
+			provider: &KeptnHTTPProvider{},
+			err:      false,
+		},
+		{
+			name:     PluginProviderName,
+			provider: &KeptnPluginProvider{},
+			err:      false,
+		},
 		{
 			name:     KeptnMetricProviderName,
 			provider: &KeptnMetricProvider{},
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"sync"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// providerRateLimiters holds one rate limiter per provider, which is shared by all evaluations and metrics
+var providerRateLimiters = &rateLimiterCache{limiters: map[types.NamespacedName]cachedRateLimiter{}}
+
+// WaitForRateLimit blocks until the provider may be queried, providers without rate limit are not blocked
+func WaitForRateLimit(ctx context.Context, provider klcv1alpha2.KeptnEvaluationProvider) error {
+	limiter := providerRateLimiters.get(provider)
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+type cachedRateLimiter struct {
+	limit   klcv1alpha2.ProviderRateLimit
+	limiter *rate.Limiter
+}
+
+// rateLimiterCache is a concurrency-safe cache of the rate limiters of the providers. A limiter is replaced
+// once the rate limit of its provider changes.
+type rateLimiterCache struct {
+	mtx      sync.Mutex
+	limiters map[types.NamespacedName]cachedRateLimiter
+}
+
+func (c *rateLimiterCache) get(provider klcv1alpha2.KeptnEvaluationProvider) *rate.Limiter {
+	key := types.NamespacedName{Namespace: provider.Namespace, Name: provider.Name}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if provider.Spec.RateLimit == nil {
+		delete(c.limiters, key)
+		return nil
+	}
+	limit := *provider.Spec.RateLimit
+	if cached, ok := c.limiters[key]; ok && cached.limit == limit {
+		return cached.limiter
+	}
+	limiter := rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.GetBurst())
+	c.limiters[key] = cachedRateLimiter{limit: limit, limiter: limiter}
+	return limiter
+}
+
+func (c *rateLimiterCache) evict(provider types.NamespacedName) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.limiters, provider)
+}
@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRateLimiterCache(t *testing.T) {
+	limiters := &rateLimiterCache{limiters: map[types.NamespacedName]cachedRateLimiter{}}
+	provider := klcv1alpha2.KeptnEvaluationProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "default"},
+	}
+	require.Nil(t, limiters.get(provider))
+
+	provider.Spec.RateLimit = &klcv1alpha2.ProviderRateLimit{RequestsPerSecond: 10}
+	limiter := limiters.get(provider)
+	require.NotNil(t, limiter)
+	require.Equal(t, 10, limiter.Burst())
+	require.Same(t, limiter, limiters.get(provider))
+
+	// providers with the same name in other namespaces have their own limiter
+	other := *provider.DeepCopy()
+	other.Namespace = "other"
+	require.NotSame(t, limiter, limiters.get(other))
+
+	provider.Spec.RateLimit = &klcv1alpha2.ProviderRateLimit{RequestsPerSecond: 10, Burst: 3}
+	changed := limiters.get(provider)
+	require.NotSame(t, limiter, changed)
+	require.Equal(t, 3, changed.Burst())
+
+	provider.Spec.RateLimit = nil
+	require.Nil(t, limiters.get(provider))
+	require.Len(t, limiters.limiters, 1)
+
+	limiters.evict(types.NamespacedName{Namespace: other.Namespace, Name: other.Name})
+	require.Empty(t, limiters.limiters)
+}
@@ -39,6 +39,16 @@ func (r TaskHandler) ReconcileTasks(ctx context.Context, phaseCtx context.Contex
 	phase := apicommon.PhaseReconcileTask
 
 	tasks, statuses := r.setupTasks(taskCreateAttributes, piWrapper)
+	execution := piWrapper.GetTaskExecution()
+	dependencyErr := execution.ValidateDependencies(getAllTasks(piWrapper))
+	if dependencyErr != nil {
+		RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "InvalidTaskExecution", dependencyErr.Error(), piWrapper.GetVersion())
+	}
+	// the states of the tasks are updated while they are reconciled, so that their dependents can start right away
+	states := make(map[string]apicommon.KeptnState, len(tasks))
+	for _, taskDefinitionName := range tasks {
+		states[taskDefinitionName] = GetItemStatus(taskDefinitionName, statuses).Status
+	}
 
 	var summary apicommon.StatusSummary
 	summary.Total = len(tasks)
@@ -55,12 +65,24 @@ func (r TaskHandler) ReconcileTasks(ctx context.Context, phaseCtx context.Contex
 			RecordEvent(r.Recorder, phase, "Normal", reconcileObject, "TaskStatusChanged", fmt.Sprintf("task status changed from %s to %s", oldstatus, taskStatus.Status), piWrapper.GetVersion())
 		}
 
-		// Check if task has already succeeded or failed
-		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed {
+		// Check if task has already succeeded, failed or was skipped
+		if taskStatus.Status == apicommon.StateSucceeded || taskStatus.Status == apicommon.StateFailed || taskStatus.Status.IsSkipped() {
 			newStatus = append(newStatus, taskStatus)
 			continue
 		}
 
+		// Check if the tasks it depends on have succeeded before the task is created
+		if taskStatus.Name == "" {
+			if start := r.checkTaskDependencies(execution.GetDependencies(taskDefinitionName, tasks), states, dependencyErr, &taskStatus); !start {
+				if taskStatus.Status.IsCompleted() {
+					RecordEvent(r.Recorder, phase, "Warning", reconcileObject, "TaskNotStarted", fmt.Sprintf("task %s did not start: %s", taskDefinitionName, taskStatus.Status), piWrapper.GetVersion())
+				}
+				states[taskDefinitionName] = taskStatus.Status
+				newStatus = append(newStatus, taskStatus)
+				continue
+			}
+		}
+
 		// Check if Task is already created
 		if taskStatus.Name != "" {
 			err := r.Client.Get(ctx, types.NamespacedName{Name: taskStatus.Name, Namespace: piWrapper.GetNamespace()}, task)
@@ -96,6 +118,7 @@ func (r TaskHandler) ReconcileTasks(ctx context.Context, phaseCtx context.Contex
 			)
 		}
 		// Update state of the Check
+		states[taskDefinitionName] = taskStatus.Status
 		newStatus = append(newStatus, taskStatus)
 	}
 
@@ -133,8 +156,33 @@ func (r TaskHandler) CreateKeptnTask(ctx context.Context, namespace string, reco
 	return newTask.Name, nil
 }
 
-func (r TaskHandler) setTaskFailureEvents(task *klcv1alpha2.KeptnTask, spanTrace trace.Span) {
-	spanTrace.AddEvent(fmt.Sprintf("task '%s' failed with reason: '%s'", task.Name, task.Status.Message), trace.WithTimestamp(time.Now().UTC()))
+func (r TaskHandler) setTaskFailureEvents(task *klcv1alpha2.KeptnTask, spanTrace trace.Span, piWrapper *interfaces.PhaseItemWrapper) {
+	msg := fmt.Sprintf("task '%s' failed with reason: '%s'", task.Name, task.GetFailureMessage())
+	spanTrace.AddEvent(msg, trace.WithTimestamp(time.Now().UTC()))
+	RecordEvent(r.Recorder, apicommon.PhaseReconcileTask, "Warning", task, "Failed", msg, piWrapper.GetVersion())
+}
+
+// checkTaskDependencies returns whether the task can start, tasks that cannot start because a task they depend on
+// did not succeed are skipped, and all tasks fail if the dependencies are invalid
+func (r TaskHandler) checkTaskDependencies(dependencies []string, states map[string]apicommon.KeptnState, dependencyErr error, taskStatus *klcv1alpha2.ItemStatus) bool {
+	if dependencyErr != nil {
+		taskStatus.Status = apicommon.StateFailed
+		taskStatus.SetEndTime()
+		return false
+	}
+	start := true
+	for _, dependency := range dependencies {
+		state := states[dependency]
+		if state.IsFailed() || state.IsSkipped() || state.IsDeprecated() {
+			taskStatus.Status = apicommon.StateSkipped
+			taskStatus.SetEndTime()
+			return false
+		}
+		if !state.IsSucceeded() {
+			start = false
+		}
+	}
+	return start
 }
 
 func (r TaskHandler) setupTasks(taskCreateAttributes CreateAttributes, piWrapper *interfaces.PhaseItemWrapper) ([]string, []klcv1alpha2.ItemStatus) {
@@ -148,10 +196,21 @@ func (r TaskHandler) setupTasks(taskCreateAttributes CreateAttributes, piWrapper
 	case apicommon.PostDeploymentCheckType:
 		tasks = piWrapper.GetPostDeploymentTasks()
 		statuses = piWrapper.GetPostDeploymentTaskStatus()
+	case apicommon.OnFailureCheckType:
+		tasks = piWrapper.GetOnFailureTasks()
+		statuses = piWrapper.GetOnFailureTaskStatus()
 	}
 	return tasks, statuses
 }
 
+// getAllTasks returns the tasks of all phases, which the task dependencies may refer to
+func getAllTasks(piWrapper *interfaces.PhaseItemWrapper) []string {
+	var tasks []string
+	tasks = append(tasks, piWrapper.GetPreDeploymentTasks()...)
+	tasks = append(tasks, piWrapper.GetPostDeploymentTasks()...)
+	return append(tasks, piWrapper.GetOnFailureTasks()...)
+}
+
 func (r TaskHandler) handleTaskNotExists(ctx context.Context, phaseCtx context.Context, taskCreateAttributes CreateAttributes, taskName string, piWrapper *interfaces.PhaseItemWrapper, reconcileObject client.Object, task *klcv1alpha2.KeptnTask, taskStatus *klcv1alpha2.ItemStatus) error {
 	taskCreateAttributes.Definition = taskName
 	taskName, err := r.CreateKeptnTask(ctx, piWrapper.GetNamespace(), reconcileObject, taskCreateAttributes)
@@ -181,7 +240,7 @@ func (r TaskHandler) handleTaskExists(phaseCtx context.Context, piWrapper *inter
 			spanTaskTrace.SetStatus(codes.Ok, "Finished")
 		} else {
 			spanTaskTrace.AddEvent(task.Name + " has failed")
-			r.setTaskFailureEvents(task, spanTaskTrace)
+			r.setTaskFailureEvents(task, spanTaskTrace, piWrapper)
 			spanTaskTrace.SetStatus(codes.Error, "Failed")
 		}
 		spanTaskTrace.End()
@@ -9,6 +9,7 @@ import (
 	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	kltfake "github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
 	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/lifecycle/interfaces"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -313,3 +314,168 @@ func TestTaskHandler_createTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskHandler_setTaskFailureEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(100)
+	handler := TaskHandler{
+		Log:      ctrl.Log.WithName("controller"),
+		Recorder: recorder,
+	}
+	task := &v1alpha2.KeptnTask{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "pre-task-def-",
+		},
+		Status: v1alpha2.KeptnTaskStatus{
+			Status:  apicommon.StateFailed,
+			Message: "attempt 1 failed: job my-job failed",
+			FailureDetails: &v1alpha2.TaskFailureDetails{
+				ExitCode: 1,
+				Reason:   "Error",
+				Logs:     "connection refused",
+			},
+		},
+	}
+	piWrapper, err := interfaces.NewPhaseItemWrapperFromClientObject(&v1alpha2.KeptnAppVersion{})
+	require.Nil(t, err)
+
+	handler.setTaskFailureEvents(task, trace.SpanFromContext(context.TODO()), piWrapper)
+
+	event := <-recorder.Events
+	require.Contains(t, event, "ReconcileTaskFailed")
+	require.Contains(t, event, "exit code: 1, reason: Error, logs:\nconnection refused")
+}
+
+func TestTaskHandler_TaskExecution(t *testing.T) {
+	tests := []struct {
+		name       string
+		execution  *v1alpha2.TaskExecution
+		statuses   []v1alpha2.ItemStatus
+		wantStatus map[string]apicommon.KeptnState
+		wantTasks  []string
+	}{
+		{
+			name:       "parallel",
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StatePending, "b": apicommon.StatePending, "c": apicommon.StatePending},
+			wantTasks:  []string{"a", "b", "c"},
+		},
+		{
+			name:       "sequential",
+			execution:  &v1alpha2.TaskExecution{Mode: v1alpha2.TaskExecutionSequential},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StatePending, "b": apicommon.StatePending, "c": apicommon.StatePending},
+			wantTasks:  []string{"a"},
+		},
+		{
+			name:      "sequential after first task",
+			execution: &v1alpha2.TaskExecution{Mode: v1alpha2.TaskExecutionSequential},
+			statuses: []v1alpha2.ItemStatus{
+				{DefinitionName: "a", Status: apicommon.StateSucceeded, Name: "pre-a"},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StateSucceeded, "b": apicommon.StatePending, "c": apicommon.StatePending},
+			wantTasks:  []string{"b"},
+		},
+		{
+			name:      "sequential after failed task",
+			execution: &v1alpha2.TaskExecution{Mode: v1alpha2.TaskExecutionSequential},
+			statuses: []v1alpha2.ItemStatus{
+				{DefinitionName: "a", Status: apicommon.StateFailed, Name: "pre-a"},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StateFailed, "b": apicommon.StateSkipped, "c": apicommon.StateSkipped},
+		},
+		{
+			name: "dag",
+			execution: &v1alpha2.TaskExecution{
+				Mode:      v1alpha2.TaskExecutionDAG,
+				DependsOn: map[string][]string{"b": {"c"}},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StatePending, "b": apicommon.StatePending, "c": apicommon.StatePending},
+			wantTasks:  []string{"a", "c"},
+		},
+		{
+			name: "dag after failed task",
+			execution: &v1alpha2.TaskExecution{
+				Mode:      v1alpha2.TaskExecutionDAG,
+				DependsOn: map[string][]string{"b": {"a"}},
+			},
+			statuses: []v1alpha2.ItemStatus{
+				{DefinitionName: "a", Status: apicommon.StateFailed, Name: "pre-a"},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StateFailed, "b": apicommon.StateSkipped, "c": apicommon.StatePending},
+			wantTasks:  []string{"c"},
+		},
+		{
+			name: "dag with cycle",
+			execution: &v1alpha2.TaskExecution{
+				Mode:      v1alpha2.TaskExecutionDAG,
+				DependsOn: map[string][]string{"a": {"b"}, "b": {"a"}},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StateFailed, "b": apicommon.StateFailed, "c": apicommon.StateFailed},
+		},
+		{
+			name: "dag with unknown task",
+			execution: &v1alpha2.TaskExecution{
+				Mode:      v1alpha2.TaskExecutionDAG,
+				DependsOn: map[string][]string{"b": {"migrate"}},
+			},
+			wantStatus: map[string]apicommon.KeptnState{"a": apicommon.StateFailed, "b": apicommon.StateFailed, "c": apicommon.StateFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v1alpha2.AddToScheme(scheme.Scheme)
+			require.Nil(t, err)
+			spanHandlerMock := kltfake.ISpanHandlerMock{
+				GetSpanFunc: func(ctx context.Context, tracer trace.Tracer, reconcileObject client.Object, phase string) (context.Context, trace.Span, error) {
+					return context.TODO(), trace.SpanFromContext(context.TODO()), nil
+				},
+				UnbindSpanFunc: func(reconcileObject client.Object, phase string) error {
+					return nil
+				},
+			}
+			fakeClient := fake.NewClientBuilder().Build()
+			handler := TaskHandler{
+				SpanHandler: &spanHandlerMock,
+				Log:         ctrl.Log.WithName("controller"),
+				Recorder:    record.NewFakeRecorder(100),
+				Client:      fakeClient,
+				Tracer:      trace.NewNoopTracerProvider().Tracer("tracer"),
+				Scheme:      scheme.Scheme,
+			}
+			appVersion := &v1alpha2.KeptnAppVersion{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "my-app-1.0.0",
+				},
+				Spec: v1alpha2.KeptnAppVersionSpec{
+					KeptnAppSpec: v1alpha2.KeptnAppSpec{
+						Version:            "1.0.0",
+						PreDeploymentTasks: []string{"a", "b", "c"},
+						TaskExecution:      tt.execution,
+					},
+					AppName: "my-app",
+				},
+				Status: v1alpha2.KeptnAppVersionStatus{
+					PreDeploymentTaskStatus: tt.statuses,
+				},
+			}
+
+			status, _, err := handler.ReconcileTasks(context.TODO(), context.TODO(), appVersion, CreateAttributes{CheckType: apicommon.PreDeploymentCheckType})
+			require.Nil(t, err)
+
+			gotStatus := map[string]apicommon.KeptnState{}
+			for _, item := range status {
+				gotStatus[item.DefinitionName] = item.Status
+			}
+			require.Equal(t, tt.wantStatus, gotStatus)
+
+			tasks := &v1alpha2.KeptnTaskList{}
+			err = fakeClient.List(context.TODO(), tasks)
+			require.Nil(t, err)
+			var gotTasks []string
+			for _, task := range tasks.Items {
+				gotTasks = append(gotTasks, task.Spec.TaskDefinition)
+			}
+			require.ElementsMatch(t, tt.wantTasks, gotTasks)
+		})
+	}
+}
@@ -9,10 +9,14 @@ var ErrCannotWrapToActiveMetricsObject = fmt.Errorf("provided object does not im
 var ErrCannotWrapToEventObject = fmt.Errorf("provided object does not implement EventObject interface")
 var ErrCannotWrapToSpanItem = fmt.Errorf("provided object does not implement SpanItem interface")
 var ErrRetryCountExceeded = fmt.Errorf("retryCount for evaluation exceeded")
+var ErrEvaluationTimeoutExceeded = fmt.Errorf("timeout for evaluation exceeded")
 var ErrNoValues = fmt.Errorf("no values")
 var ErrInvalidOperator = fmt.Errorf("invalid operator")
 var ErrCannotMarshalParams = fmt.Errorf("could not marshal parameters")
 var ErrUnsupportedWorkloadInstanceResourceReference = fmt.Errorf("unsupported Resource Reference")
+var ErrInvalidTaskOutput = fmt.Errorf("task output is not a JSON object of strings")
+var ErrFunctionAndContainerDefined = fmt.Errorf("task definition must not have both a function and a container")
+var ErrNoFunctionOrContainerDefined = fmt.Errorf("task definition must have either a function or a container")
 
 var ErrCannotRetrieveInstancesMsg = "could not retrieve instances: %w"
 var ErrCannotFetchAppMsg = "could not retrieve KeptnApp: %w"
@@ -42,6 +42,12 @@ import (
 //			GetNamespaceFunc: func() string {
 //				panic("mock out the GetNamespace method")
 //			},
+//			GetOnFailureTaskStatusFunc: func() []klcv1alpha2.ItemStatus {
+//				panic("mock out the GetOnFailureTaskStatus method")
+//			},
+//			GetOnFailureTasksFunc: func() []string {
+//				panic("mock out the GetOnFailureTasks method")
+//			},
 //			GetParentNameFunc: func() string {
 //				panic("mock out the GetParentName method")
 //			},
@@ -81,6 +87,9 @@ import (
 //			GetStateFunc: func() apicommon.KeptnState {
 //				panic("mock out the GetState method")
 //			},
+//			GetTaskExecutionFunc: func() *klcv1alpha2.TaskExecution {
+//				panic("mock out the GetTaskExecution method")
+//			},
 //			GetVersionFunc: func() string {
 //				panic("mock out the GetVersion method")
 //			},
@@ -127,6 +136,12 @@ type PhaseItemMock struct {
 	// GetNamespaceFunc mocks the GetNamespace method.
 	GetNamespaceFunc func() string
 
+	// GetOnFailureTaskStatusFunc mocks the GetOnFailureTaskStatus method.
+	GetOnFailureTaskStatusFunc func() []klcv1alpha2.ItemStatus
+
+	// GetOnFailureTasksFunc mocks the GetOnFailureTasks method.
+	GetOnFailureTasksFunc func() []string
+
 	// GetParentNameFunc mocks the GetParentName method.
 	GetParentNameFunc func() string
 
@@ -166,6 +181,9 @@ type PhaseItemMock struct {
 	// GetStateFunc mocks the GetState method.
 	GetStateFunc func() apicommon.KeptnState
 
+	// GetTaskExecutionFunc mocks the GetTaskExecution method.
+	GetTaskExecutionFunc func() *klcv1alpha2.TaskExecution
+
 	// GetVersionFunc mocks the GetVersion method.
 	GetVersionFunc func() string
 
@@ -217,6 +235,12 @@ type PhaseItemMock struct {
 		// GetNamespace holds details about calls to the GetNamespace method.
 		GetNamespace []struct {
 		}
+		// GetOnFailureTaskStatus holds details about calls to the GetOnFailureTaskStatus method.
+		GetOnFailureTaskStatus []struct {
+		}
+		// GetOnFailureTasks holds details about calls to the GetOnFailureTasks method.
+		GetOnFailureTasks []struct {
+		}
 		// GetParentName holds details about calls to the GetParentName method.
 		GetParentName []struct {
 		}
@@ -256,6 +280,9 @@ type PhaseItemMock struct {
 		// GetState holds details about calls to the GetState method.
 		GetState []struct {
 		}
+		// GetTaskExecution holds details about calls to the GetTaskExecution method.
+		GetTaskExecution []struct {
+		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
 		}
@@ -286,6 +313,8 @@ type PhaseItemMock struct {
 	lockGetCurrentPhase                       sync.RWMutex
 	lockGetEndTime                            sync.RWMutex
 	lockGetNamespace                          sync.RWMutex
+	lockGetOnFailureTaskStatus                sync.RWMutex
+	lockGetOnFailureTasks                     sync.RWMutex
 	lockGetParentName                         sync.RWMutex
 	lockGetPostDeploymentEvaluationTaskStatus sync.RWMutex
 	lockGetPostDeploymentEvaluations          sync.RWMutex
@@ -299,6 +328,7 @@ type PhaseItemMock struct {
 	lockGetSpanAttributes                     sync.RWMutex
 	lockGetStartTime                          sync.RWMutex
 	lockGetState                              sync.RWMutex
+	lockGetTaskExecution                      sync.RWMutex
 	lockGetVersion                            sync.RWMutex
 	lockIsEndTimeSet                          sync.RWMutex
 	lockSetCurrentPhase                       sync.RWMutex
@@ -545,6 +575,60 @@ func (mock *PhaseItemMock) GetNamespaceCalls() []struct {
 	return calls
 }
 
+// GetOnFailureTaskStatus calls GetOnFailureTaskStatusFunc.
+func (mock *PhaseItemMock) GetOnFailureTaskStatus() []klcv1alpha2.ItemStatus {
+	if mock.GetOnFailureTaskStatusFunc == nil {
+		panic("PhaseItemMock.GetOnFailureTaskStatusFunc: method is nil but PhaseItem.GetOnFailureTaskStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetOnFailureTaskStatus.Lock()
+	mock.calls.GetOnFailureTaskStatus = append(mock.calls.GetOnFailureTaskStatus, callInfo)
+	mock.lockGetOnFailureTaskStatus.Unlock()
+	return mock.GetOnFailureTaskStatusFunc()
+}
+
+// GetOnFailureTaskStatusCalls gets all the calls that were made to GetOnFailureTaskStatus.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetOnFailureTaskStatusCalls())
+func (mock *PhaseItemMock) GetOnFailureTaskStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetOnFailureTaskStatus.RLock()
+	calls = mock.calls.GetOnFailureTaskStatus
+	mock.lockGetOnFailureTaskStatus.RUnlock()
+	return calls
+}
+
+// GetOnFailureTasks calls GetOnFailureTasksFunc.
+func (mock *PhaseItemMock) GetOnFailureTasks() []string {
+	if mock.GetOnFailureTasksFunc == nil {
+		panic("PhaseItemMock.GetOnFailureTasksFunc: method is nil but PhaseItem.GetOnFailureTasks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetOnFailureTasks.Lock()
+	mock.calls.GetOnFailureTasks = append(mock.calls.GetOnFailureTasks, callInfo)
+	mock.lockGetOnFailureTasks.Unlock()
+	return mock.GetOnFailureTasksFunc()
+}
+
+// GetOnFailureTasksCalls gets all the calls that were made to GetOnFailureTasks.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetOnFailureTasksCalls())
+func (mock *PhaseItemMock) GetOnFailureTasksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetOnFailureTasks.RLock()
+	calls = mock.calls.GetOnFailureTasks
+	mock.lockGetOnFailureTasks.RUnlock()
+	return calls
+}
+
 // GetParentName calls GetParentNameFunc.
 func (mock *PhaseItemMock) GetParentName() string {
 	if mock.GetParentNameFunc == nil {
@@ -896,6 +980,33 @@ func (mock *PhaseItemMock) GetStateCalls() []struct {
 	return calls
 }
 
+// GetTaskExecution calls GetTaskExecutionFunc.
+func (mock *PhaseItemMock) GetTaskExecution() *klcv1alpha2.TaskExecution {
+	if mock.GetTaskExecutionFunc == nil {
+		panic("PhaseItemMock.GetTaskExecutionFunc: method is nil but PhaseItem.GetTaskExecution was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTaskExecution.Lock()
+	mock.calls.GetTaskExecution = append(mock.calls.GetTaskExecution, callInfo)
+	mock.lockGetTaskExecution.Unlock()
+	return mock.GetTaskExecutionFunc()
+}
+
+// GetTaskExecutionCalls gets all the calls that were made to GetTaskExecution.
+// Check the length with:
+//
+//	len(mockedPhaseItem.GetTaskExecutionCalls())
+func (mock *PhaseItemMock) GetTaskExecutionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTaskExecution.RLock()
+	calls = mock.calls.GetTaskExecution
+	mock.lockGetTaskExecution.RUnlock()
+	return calls
+}
+
 // GetVersion calls GetVersionFunc.
 func (mock *PhaseItemMock) GetVersion() string {
 	if mock.GetVersionFunc == nil {
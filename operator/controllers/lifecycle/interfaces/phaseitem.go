@@ -30,8 +30,11 @@ type PhaseItem interface {
 	GetAppName() string
 	GetPreDeploymentTasks() []string
 	GetPostDeploymentTasks() []string
+	GetTaskExecution() *klcv1alpha2.TaskExecution
 	GetPreDeploymentTaskStatus() []klcv1alpha2.ItemStatus
 	GetPostDeploymentTaskStatus() []klcv1alpha2.ItemStatus
+	GetOnFailureTasks() []string
+	GetOnFailureTaskStatus() []klcv1alpha2.ItemStatus
 	GetPreDeploymentEvaluations() []string
 	GetPostDeploymentEvaluations() []string
 	GetPreDeploymentEvaluationTaskStatus() []klcv1alpha2.ItemStatus
@@ -115,6 +118,10 @@ func (pw PhaseItemWrapper) GetPostDeploymentTasks() []string {
 	return pw.Obj.GetPostDeploymentTasks()
 }
 
+func (pw PhaseItemWrapper) GetTaskExecution() *klcv1alpha2.TaskExecution {
+	return pw.Obj.GetTaskExecution()
+}
+
 func (pw PhaseItemWrapper) GetPreDeploymentTaskStatus() []klcv1alpha2.ItemStatus {
 	return pw.Obj.GetPreDeploymentTaskStatus()
 }
@@ -123,6 +130,14 @@ func (pw PhaseItemWrapper) GetPostDeploymentTaskStatus() []klcv1alpha2.ItemStatu
 	return pw.Obj.GetPostDeploymentTaskStatus()
 }
 
+func (pw PhaseItemWrapper) GetOnFailureTasks() []string {
+	return pw.Obj.GetOnFailureTasks()
+}
+
+func (pw PhaseItemWrapper) GetOnFailureTaskStatus() []klcv1alpha2.ItemStatus {
+	return pw.Obj.GetOnFailureTaskStatus()
+}
+
 func (pw PhaseItemWrapper) GetPreDeploymentEvaluations() []string {
 	return pw.Obj.GetPreDeploymentEvaluations()
 }
@@ -77,12 +77,21 @@ func TestPhaseItem(t *testing.T) {
 		GetPostDeploymentTasksFunc: func() []string {
 			return nil
 		},
+		GetTaskExecutionFunc: func() *v1alpha2.TaskExecution {
+			return nil
+		},
 		GetPreDeploymentTaskStatusFunc: func() []v1alpha2.ItemStatus {
 			return nil
 		},
 		GetPostDeploymentTaskStatusFunc: func() []v1alpha2.ItemStatus {
 			return nil
 		},
+		GetOnFailureTasksFunc: func() []string {
+			return nil
+		},
+		GetOnFailureTaskStatusFunc: func() []v1alpha2.ItemStatus {
+			return nil
+		},
 		GetPreDeploymentEvaluationsFunc: func() []string {
 			return nil
 		},
@@ -157,12 +166,21 @@ func TestPhaseItem(t *testing.T) {
 	_ = wrapper.GetPostDeploymentTasks()
 	require.Len(t, phaseItemMock.GetPostDeploymentTasksCalls(), 1)
 
+	_ = wrapper.GetTaskExecution()
+	require.Len(t, phaseItemMock.GetTaskExecutionCalls(), 1)
+
 	_ = wrapper.GetPreDeploymentTaskStatus()
 	require.Len(t, phaseItemMock.GetPreDeploymentTaskStatusCalls(), 1)
 
 	_ = wrapper.GetPostDeploymentTaskStatus()
 	require.Len(t, phaseItemMock.GetPostDeploymentTaskStatusCalls(), 1)
 
+	_ = wrapper.GetOnFailureTasks()
+	require.Len(t, phaseItemMock.GetOnFailureTasksCalls(), 1)
+
+	_ = wrapper.GetOnFailureTaskStatus()
+	require.Len(t, phaseItemMock.GetOnFailureTaskStatusCalls(), 1)
+
 	_ = wrapper.GetPreDeploymentEvaluations()
 	require.Len(t, phaseItemMock.GetPreDeploymentEvaluationsCalls(), 1)
 
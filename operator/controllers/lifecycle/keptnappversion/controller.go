@@ -99,6 +99,18 @@ func (r *KeptnAppVersionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		r.Log.Error(err, "could not get span")
 	}
 
+	// once a phase has failed, only the on-failure tasks are left to run
+	if appVersion.Status.Status.IsFailed() && len(appVersion.Spec.OnFailureTasks) > 0 {
+		if appVersion.IsOnFailureCompleted() {
+			return ctrl.Result{}, nil
+		}
+		reconcileOnFailure := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+			return r.reconcilePrePostDeployment(ctx, phaseCtx, appVersion, apicommon.OnFailureCheckType)
+		}
+		result, err := phaseHandler.HandleOnFailurePhase(ctx, ctxAppTrace, r.Tracer, appVersion, apicommon.PhaseAppOnFailure, span, reconcileOnFailure)
+		return result.Result, err
+	}
+
 	if appVersion.Status.CurrentPhase == "" {
 		appVersion.SetSpanAttributes(spanAppTrace)
 		spanAppTrace.AddEvent("App Version Pre-Deployment Tasks started", trace.WithTimestamp(time.Now()))
@@ -15,6 +15,7 @@ import (
 	"github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -120,6 +121,59 @@ func TestKeptnAppVersionReconciler_reconcile(t *testing.T) {
 
 }
 
+func TestKeptnAppVersionReconciler_reconcileOnFailure(t *testing.T) {
+	r, _, _, _ := setupReconciler()
+
+	appVersion := &lfcv1alpha2.KeptnAppVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myfailedapp-1.0.0",
+			Namespace: "default",
+		},
+		Spec: lfcv1alpha2.KeptnAppVersionSpec{
+			KeptnAppSpec: lfcv1alpha2.KeptnAppSpec{
+				Version:        "1.0.0",
+				OnFailureTasks: []string{"rollback"},
+			},
+			AppName: "myfailedapp",
+		},
+		Status: lfcv1alpha2.KeptnAppVersionStatus{
+			CurrentPhase:         apicommon.PhaseAppPostDeployment.ShortName,
+			PreDeploymentStatus:  apicommon.StateSucceeded,
+			PostDeploymentStatus: apicommon.StateFailed,
+			Status:               apicommon.StateFailed,
+		},
+	}
+	err := r.Client.Create(context.TODO(), appVersion)
+	require.Nil(t, err)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "myfailedapp-1.0.0"}}
+	result, err := r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.True(t, result.Requeue)
+
+	err = r.Client.Get(context.TODO(), req.NamespacedName, appVersion)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, appVersion.Status.Status)
+	require.Equal(t, apicommon.PhaseAppOnFailure.ShortName, appVersion.Status.CurrentPhase)
+	require.False(t, appVersion.IsOnFailureCompleted())
+	require.Len(t, appVersion.Status.OnFailureTaskStatus, 1)
+	require.Equal(t, "rollback", appVersion.Status.OnFailureTaskStatus[0].DefinitionName)
+
+	task := &lfcv1alpha2.KeptnTask{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: appVersion.Status.OnFailureTaskStatus[0].Name}, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.OnFailureCheckType, task.Spec.Type)
+
+	// the deployment is not resumed once the on-failure tasks are done
+	appVersion.Status.OnFailureStatus = apicommon.StateSucceeded
+	err = r.Client.Status().Update(context.TODO(), appVersion)
+	require.Nil(t, err)
+
+	result, err = r.Reconcile(context.TODO(), req)
+	require.Nil(t, err)
+	require.Equal(t, ctrl.Result{}, result)
+}
+
 func createFinishedAppVersionStatus() lfcv1alpha2.KeptnAppVersionStatus {
 	return lfcv1alpha2.KeptnAppVersionStatus{
 		CurrentPhase:                       apicommon.PhaseCompleted.ShortName,
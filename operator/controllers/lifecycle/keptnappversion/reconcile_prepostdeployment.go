@@ -38,6 +38,9 @@ func (r *KeptnAppVersionReconciler) reconcilePrePostDeployment(ctx context.Conte
 	case apicommon.PostDeploymentCheckType:
 		appVersion.Status.PostDeploymentStatus = overallState
 		appVersion.Status.PostDeploymentTaskStatus = newStatus
+	case apicommon.OnFailureCheckType:
+		appVersion.Status.OnFailureStatus = overallState
+		appVersion.Status.OnFailureTaskStatus = newStatus
 	}
 
 	// Write Status Field
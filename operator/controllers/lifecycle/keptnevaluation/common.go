@@ -1,39 +1,180 @@
 package keptnevaluation
 
 import (
-	"fmt"
 	"math"
 	"strconv"
+	"time"
 
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// maxRawValueSize limits the raw provider response stored per objective, so that the status stays small
+const maxRawValueSize = 4 * 1024
+
+// maxHistoryLength is the number of evaluation attempts kept in the history
+const maxHistoryLength = 10
+
+type evaluationScore struct {
+	Percentage float64
+	Pass       bool
+	Warning    bool
+}
+
 func checkValue(objective klcv1alpha2.Objective, item *klcv1alpha2.EvaluationStatusItem) (bool, error) {
+	return checkTarget(objective.EvaluationTarget, item)
+}
 
-	if len(item.Value) == 0 || len(objective.EvaluationTarget) == 0 {
-		return false, fmt.Errorf("no values")
+func checkWarning(objective klcv1alpha2.Objective, item *klcv1alpha2.EvaluationStatusItem) (bool, error) {
+	if len(objective.WarningTarget) == 0 {
+		return false, nil
 	}
+	return checkTarget(objective.WarningTarget, item)
+}
 
-	eval := objective.EvaluationTarget[1:]
-	sign := objective.EvaluationTarget[:1]
+func checkTarget(evaluationTarget string, item *klcv1alpha2.EvaluationStatusItem) (bool, error) {
+
+	if len(item.Value) == 0 || len(evaluationTarget) == 0 {
+		return false, controllererrors.ErrNoValues
+	}
+
+	target, err := apicommon.ParseEvaluationTarget(evaluationTarget)
+	if err != nil {
+		return false, err
+	}
+
+	if target.IsRelative() {
+		// without previous results there is nothing to compare with, so only the absolute conditions are checked
+		if len(item.Baseline) == 0 {
+			target = target.DropRelative()
+		} else {
+			baseline, err := strconv.ParseFloat(item.Baseline, 64)
+			if err != nil {
+				return false, err
+			}
+			target = target.ResolveBaseline(baseline)
+		}
+	}
 
 	resultValue, err := strconv.ParseFloat(item.Value, 64)
 	if err != nil || math.IsNaN(resultValue) {
 		return false, err
 	}
 
-	compareValue, err := strconv.ParseFloat(eval, 64)
-	if err != nil || math.IsNaN(compareValue) {
-		return false, err
+	return target.IsFulfilledBy(resultValue), nil
+}
+
+// computeScore sums up the weights of the passed objectives, warnings count half, and compares the
+// resulting percentage of the maximum score with the thresholds of the definition
+func computeScore(definition klcv1alpha2.KeptnEvaluationDefinitionSpec, items map[string]klcv1alpha2.EvaluationStatusItem) evaluationScore {
+	var achieved, maximum float64
+	keySLIFailed := false
+	for _, objective := range definition.Objectives {
+		weight := float64(objective.GetWeight())
+		maximum += weight
+		switch items[objective.Name].Status {
+		case apicommon.StateSucceeded:
+			achieved += weight
+		case apicommon.StateWarning:
+			achieved += weight / 2
+		default:
+			if objective.KeySLI {
+				keySLIFailed = true
+			}
+		}
 	}
 
-	// choose comparator
-	switch sign {
-	case ">":
-		return resultValue > compareValue, nil
-	case "<":
-		return resultValue < compareValue, nil
-	default:
-		return false, fmt.Errorf("invalid operator")
+	score := evaluationScore{Percentage: 100}
+	if maximum > 0 {
+		score.Percentage = achieved / maximum * 100
+	}
+	if keySLIFailed {
+		return score
+	}
+	score.Pass = score.Percentage >= float64(definition.TotalScore.GetPassPercentage())
+	score.Warning = !score.Pass && definition.TotalScore.WarningPercentage > 0 &&
+		score.Percentage >= float64(definition.TotalScore.WarningPercentage)
+	return score
+}
+
+// hasViolatedObjective checks whether an objective was queried successfully but missed its targets,
+// unlike failed queries, such objectives are not expected to pass when the evaluation is retried right away
+func hasViolatedObjective(evaluation *klcv1alpha2.KeptnEvaluation) bool {
+	for _, item := range evaluation.Status.EvaluationStatus {
+		if item.Status.IsFailed() && item.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func cupRawValue(value []byte) []byte {
+	if len(value) > maxRawValueSize {
+		return value[:maxRawValueSize]
+	}
+	return value
+}
+
+// newAttempt summarizes the current evaluation status, the objectives are in the order of the definition
+func newAttempt(evaluation *klcv1alpha2.KeptnEvaluation, objectives []klcv1alpha2.Objective) klcv1alpha2.EvaluationAttempt {
+	attempt := klcv1alpha2.EvaluationAttempt{
+		Attempt: evaluation.Status.RetryCount,
+		Time:    metav1.Now(),
+		Score:   evaluation.Status.Score,
+	}
+	for _, objective := range objectives {
+		item := evaluation.Status.EvaluationStatus[objective.Name]
+		attempt.Objectives = append(attempt.Objectives, klcv1alpha2.ObjectiveResult{
+			Name:   objective.Name,
+			Value:  item.Value,
+			Status: item.Status,
+		})
+	}
+	return attempt
+}
+
+// appendAttempt adds the attempt to the history and drops the oldest attempts beyond maxHistoryLength
+func appendAttempt(history []klcv1alpha2.EvaluationAttempt, attempt klcv1alpha2.EvaluationAttempt) []klcv1alpha2.EvaluationAttempt {
+	history = append(history, attempt)
+	if len(history) > maxHistoryLength {
+		history = history[len(history)-maxHistoryLength:]
+	}
+	return history
+}
+
+// isEvaluationExceeded checks whether the evaluation used up its retries, or its time if a timeout is set.
+// Evaluations that have finished are never exceeded.
+func isEvaluationExceeded(evaluation *klcv1alpha2.KeptnEvaluation) bool {
+	if evaluation.Status.OverallStatus.IsCompleted() {
+		return false
+	}
+	if evaluation.Spec.Timeout.Duration > 0 {
+		return !evaluation.Status.StartTime.IsZero() && time.Since(evaluation.Status.StartTime.Time) >= evaluation.Spec.Timeout.Duration
+	}
+	return evaluation.Status.RetryCount >= evaluation.Spec.Retries
+}
+
+// nextRetryInterval returns the time to wait after the given number of attempts, random must be in [0, 1)
+// and decides where the interval ends up within the jitter
+func nextRetryInterval(spec klcv1alpha2.KeptnEvaluationSpec, retryCount int, random float64) time.Duration {
+	interval := spec.RetryInterval.Duration
+	backoff := spec.Backoff
+	if backoff == nil {
+		return interval
+	}
+
+	maxInterval := backoff.GetMaxInterval()
+	for i := 1; i < retryCount && interval < maxInterval; i++ {
+		interval *= time.Duration(backoff.GetFactor())
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	if backoff.JitterPercent > 0 {
+		jitter := float64(interval) * float64(backoff.JitterPercent) / 100
+		interval += time.Duration(jitter * (2*random - 1))
 	}
+	return interval
 }
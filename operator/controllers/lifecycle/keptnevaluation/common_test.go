@@ -2,9 +2,12 @@ package keptnevaluation
 
 import (
 	"testing"
+	"time"
 
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestCheckValue(t *testing.T) {
@@ -172,6 +175,51 @@ func TestCheckValue(t *testing.T) {
 			result: false,
 			err:    false,
 		},
+		{
+			name: "10>=10",
+			obj: klcv1alpha2.Objective{
+				Name:             "testytest",
+				Query:            "mymetric",
+				EvaluationTarget: ">=10",
+			},
+			item: &klcv1alpha2.EvaluationStatusItem{
+				Value:   "10",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: true,
+			err:    false,
+		},
+		{
+			name: "150 in [100,200]",
+			obj: klcv1alpha2.Objective{
+				Name:             "testytest",
+				Query:            "mymetric",
+				EvaluationTarget: "[100,200]",
+			},
+			item: &klcv1alpha2.EvaluationStatusItem{
+				Value:   "150",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: true,
+			err:    false,
+		},
+		{
+			name: "50 <10 or >100",
+			obj: klcv1alpha2.Objective{
+				Name:             "testytest",
+				Query:            "mymetric",
+				EvaluationTarget: "<10 || >100",
+			},
+			item: &klcv1alpha2.EvaluationStatusItem{
+				Value:   "50",
+				Status:  "all good",
+				Message: "all good",
+			},
+			result: false,
+			err:    false,
+		},
 		{
 			name: "invalid op",
 			obj: klcv1alpha2.Objective{
@@ -200,3 +248,208 @@ func TestCheckValue(t *testing.T) {
 
 	}
 }
+
+func TestCheckWarning(t *testing.T) {
+	item := &klcv1alpha2.EvaluationStatusItem{Value: "15"}
+
+	warning, err := checkWarning(klcv1alpha2.Objective{EvaluationTarget: "<10"}, item)
+	require.Nil(t, err)
+	require.False(t, warning)
+
+	warning, err = checkWarning(klcv1alpha2.Objective{EvaluationTarget: "<10", WarningTarget: "<20"}, item)
+	require.Nil(t, err)
+	require.True(t, warning)
+
+	warning, err = checkWarning(klcv1alpha2.Objective{EvaluationTarget: "<10", WarningTarget: "<12"}, item)
+	require.Nil(t, err)
+	require.False(t, warning)
+}
+
+func TestComputeScore(t *testing.T) {
+	objectives := []klcv1alpha2.Objective{
+		{Name: "a", Weight: 2},
+		{Name: "b"},
+		{Name: "c", KeySLI: true},
+	}
+	tests := []struct {
+		name       string
+		totalScore klcv1alpha2.TotalScore
+		items      map[string]klcv1alpha2.EvaluationStatusItem
+		want       evaluationScore
+	}{
+		{
+			name: "all passed",
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateSucceeded},
+				"b": {Status: apicommon.StateSucceeded},
+				"c": {Status: apicommon.StateSucceeded},
+			},
+			want: evaluationScore{Percentage: 100, Pass: true},
+		},
+		{
+			name: "one failed, default total score requires all",
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateSucceeded},
+				"b": {Status: apicommon.StateFailed},
+				"c": {Status: apicommon.StateSucceeded},
+			},
+			want: evaluationScore{Percentage: 75},
+		},
+		{
+			name:       "one failed, enough for pass",
+			totalScore: klcv1alpha2.TotalScore{PassPercentage: 75},
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateSucceeded},
+				"b": {Status: apicommon.StateFailed},
+				"c": {Status: apicommon.StateSucceeded},
+			},
+			want: evaluationScore{Percentage: 75, Pass: true},
+		},
+		{
+			name:       "warning counts half",
+			totalScore: klcv1alpha2.TotalScore{PassPercentage: 90, WarningPercentage: 70},
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateWarning},
+				"b": {Status: apicommon.StateSucceeded},
+				"c": {Status: apicommon.StateSucceeded},
+			},
+			want: evaluationScore{Percentage: 75, Warning: true},
+		},
+		{
+			name:       "below warning",
+			totalScore: klcv1alpha2.TotalScore{PassPercentage: 90, WarningPercentage: 80},
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateWarning},
+				"b": {Status: apicommon.StateSucceeded},
+				"c": {Status: apicommon.StateSucceeded},
+			},
+			want: evaluationScore{Percentage: 75},
+		},
+		{
+			name:       "failed key sli",
+			totalScore: klcv1alpha2.TotalScore{PassPercentage: 50, WarningPercentage: 25},
+			items: map[string]klcv1alpha2.EvaluationStatusItem{
+				"a": {Status: apicommon.StateSucceeded},
+				"b": {Status: apicommon.StateSucceeded},
+				"c": {Status: apicommon.StateFailed},
+			},
+			want: evaluationScore{Percentage: 75},
+		},
+		{
+			name:  "missing status",
+			items: map[string]klcv1alpha2.EvaluationStatusItem{},
+			want:  evaluationScore{Percentage: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := klcv1alpha2.KeptnEvaluationDefinitionSpec{
+				Objectives: objectives,
+				TotalScore: tt.totalScore,
+			}
+			require.Equal(t, tt.want, computeScore(definition, tt.items))
+		})
+	}
+}
+
+func TestCupRawValue(t *testing.T) {
+	require.Equal(t, []byte("raw"), cupRawValue([]byte("raw")))
+	require.Nil(t, cupRawValue(nil))
+	require.Len(t, cupRawValue(make([]byte, maxRawValueSize+1)), maxRawValueSize)
+}
+
+func TestAppendAttempt(t *testing.T) {
+	var history []klcv1alpha2.EvaluationAttempt
+	for i := 1; i <= maxHistoryLength+2; i++ {
+		history = appendAttempt(history, klcv1alpha2.EvaluationAttempt{Attempt: i})
+	}
+	require.Len(t, history, maxHistoryLength)
+	require.Equal(t, 3, history[0].Attempt)
+	require.Equal(t, maxHistoryLength+2, history[maxHistoryLength-1].Attempt)
+}
+
+func TestNextRetryInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		backoff    *klcv1alpha2.EvaluationBackoff
+		retryCount int
+		random     float64
+		want       time.Duration
+	}{
+		{
+			name:       "fixed interval",
+			retryCount: 5,
+			want:       10 * time.Second,
+		},
+		{
+			name:       "first retry",
+			backoff:    &klcv1alpha2.EvaluationBackoff{},
+			retryCount: 1,
+			want:       10 * time.Second,
+		},
+		{
+			name:       "default factor",
+			backoff:    &klcv1alpha2.EvaluationBackoff{},
+			retryCount: 3,
+			want:       40 * time.Second,
+		},
+		{
+			name:       "custom factor",
+			backoff:    &klcv1alpha2.EvaluationBackoff{Factor: 3},
+			retryCount: 3,
+			want:       90 * time.Second,
+		},
+		{
+			name:       "capped",
+			backoff:    &klcv1alpha2.EvaluationBackoff{MaxInterval: metav1.Duration{Duration: time.Minute}},
+			retryCount: 100,
+			want:       time.Minute,
+		},
+		{
+			name:       "jitter low",
+			backoff:    &klcv1alpha2.EvaluationBackoff{JitterPercent: 50},
+			retryCount: 1,
+			random:     0,
+			want:       5 * time.Second,
+		},
+		{
+			name:       "jitter high",
+			backoff:    &klcv1alpha2.EvaluationBackoff{JitterPercent: 50},
+			retryCount: 2,
+			random:     0.75,
+			want:       25 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := klcv1alpha2.KeptnEvaluationSpec{
+				RetryInterval: metav1.Duration{Duration: 10 * time.Second},
+				Backoff:       tt.backoff,
+			}
+			require.Equal(t, tt.want, nextRetryInterval(spec, tt.retryCount, tt.random))
+		})
+	}
+}
+
+func TestIsEvaluationExceeded(t *testing.T) {
+	evaluation := &klcv1alpha2.KeptnEvaluation{
+		Spec: klcv1alpha2.KeptnEvaluationSpec{Retries: 2},
+		Status: klcv1alpha2.KeptnEvaluationStatus{
+			RetryCount: 1,
+			StartTime:  metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+	}
+	require.False(t, isEvaluationExceeded(evaluation))
+	evaluation.Status.RetryCount = 2
+	require.True(t, isEvaluationExceeded(evaluation))
+
+	// the timeout replaces the retries
+	evaluation.Spec.Timeout = metav1.Duration{Duration: 2 * time.Hour}
+	require.False(t, isEvaluationExceeded(evaluation))
+	evaluation.Spec.Timeout = metav1.Duration{Duration: 30 * time.Minute}
+	require.True(t, isEvaluationExceeded(evaluation))
+
+	// an evaluation that succeeded before its timeout stays succeeded
+	evaluation.Status.OverallStatus = apicommon.StateSucceeded
+	require.False(t, isEvaluationExceeded(evaluation))
+}
@@ -0,0 +1,121 @@
+package keptnevaluation
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/providers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// comparisonIndex is the field index of the evaluations by the app, workload, check type and definition they
+// evaluated, so that the evaluations of previous versions are found without listing all evaluations of the namespace
+const comparisonIndex = "keptnEvaluationComparison"
+
+// fetchPreviousEvaluations returns the successful evaluations of previous versions that used the same definition,
+// most recent first. The previous versions are found by following the PreviousVersion of the evaluations back from
+// the given one. Nothing is fetched if no objective of the definition has a relative target.
+func (r *KeptnEvaluationReconciler) fetchPreviousEvaluations(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, evaluationDefinition *klcv1alpha2.KeptnEvaluationDefinition) ([]klcv1alpha2.KeptnEvaluation, error) {
+	if !hasRelativeObjectives(evaluationDefinition.Spec.Objectives) {
+		return nil, nil
+	}
+
+	evaluations := &klcv1alpha2.KeptnEvaluationList{}
+	if err := r.Client.List(ctx, evaluations, client.InNamespace(evaluation.Namespace), client.MatchingFields{comparisonIndex: getComparisonKey(evaluation)}); err != nil {
+		return nil, err
+	}
+
+	byVersion := map[string][]klcv1alpha2.KeptnEvaluation{}
+	for _, candidate := range evaluations.Items {
+		// the index only returns evaluations of the same workload or app with the same definition
+		if candidate.Name != evaluation.Name {
+			byVersion[getEvaluatedVersion(candidate)] = append(byVersion[getEvaluatedVersion(candidate)], candidate)
+		}
+	}
+
+	var previous []klcv1alpha2.KeptnEvaluation
+	visited := map[string]bool{getEvaluatedVersion(*evaluation): true}
+	version := evaluation.Spec.PreviousVersion
+	for version != "" && !visited[version] && len(byVersion[version]) > 0 {
+		visited[version] = true
+		candidates := byVersion[version]
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Status.EndTime.After(candidates[j].Status.EndTime.Time)
+		})
+		for _, candidate := range candidates {
+			if candidate.Status.OverallStatus.IsSucceeded() {
+				previous = append(previous, candidate)
+				break
+			}
+		}
+		version = candidates[0].Spec.PreviousVersion
+	}
+	return previous, nil
+}
+
+// indexComparisonKey returns the value of the evaluation in the comparisonIndex
+func indexComparisonKey(obj client.Object) []string {
+	evaluation, ok := obj.(*klcv1alpha2.KeptnEvaluation)
+	if !ok {
+		return nil
+	}
+	return []string{getComparisonKey(evaluation)}
+}
+
+func getComparisonKey(evaluation *klcv1alpha2.KeptnEvaluation) string {
+	return strings.Join([]string{evaluation.Spec.AppName, evaluation.Spec.Workload, string(evaluation.Spec.Type), evaluation.Spec.EvaluationDefinition}, "/")
+}
+
+func getEvaluatedVersion(evaluation klcv1alpha2.KeptnEvaluation) string {
+	if evaluation.Spec.Workload != "" {
+		return evaluation.Spec.WorkloadVersion
+	}
+	return evaluation.Spec.AppVersion
+}
+
+func hasRelativeObjectives(objectives []klcv1alpha2.Objective) bool {
+	for _, objective := range objectives {
+		if isRelativeObjective(objective) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRelativeObjective(objective klcv1alpha2.Objective) bool {
+	for _, t := range []string{objective.EvaluationTarget, objective.WarningTarget} {
+		if target, err := apicommon.ParseEvaluationTarget(t); err == nil && target.IsRelative() {
+			return true
+		}
+	}
+	return false
+}
+
+// computeBaseline aggregates the values the objective had in the given previous evaluations,
+// it returns false if none of them has a value for the objective
+func computeBaseline(objective klcv1alpha2.Objective, previous []klcv1alpha2.KeptnEvaluation) (float64, bool) {
+	comparison := objective.GetComparison()
+	var values []float64
+	for _, evaluation := range previous {
+		if len(values) == comparison.NumberOfResults {
+			break
+		}
+		item, ok := evaluation.Status.EvaluationStatus[objective.Name]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(item.Value, 64)
+		if err != nil || math.IsNaN(value) {
+			continue
+		}
+		values = append(values, value)
+	}
+	// there is no baseline without values, the aggregate function is checked by the CRD
+	baseline, err := providers.Aggregate(comparison.AggregateFunction, values)
+	return baseline, err == nil
+}
@@ -0,0 +1,167 @@
+package keptnevaluation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makePreviousEvaluation(name string, version string, status apicommon.KeptnState, endTime time.Time, value string) *klcv1alpha2.KeptnEvaluation {
+	return &klcv1alpha2.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: klcv1alpha2.KeptnEvaluationSpec{
+			Workload:             "my-workload",
+			WorkloadVersion:      version,
+			AppName:              "my-app",
+			EvaluationDefinition: "my-definition",
+			Type:                 apicommon.PostDeploymentEvaluationCheckType,
+		},
+		Status: klcv1alpha2.KeptnEvaluationStatus{
+			OverallStatus: status,
+			EndTime:       metav1.NewTime(endTime),
+			EvaluationStatus: map[string]klcv1alpha2.EvaluationStatusItem{
+				"latency": {Value: value, Status: apicommon.StateSucceeded},
+			},
+		},
+	}
+}
+
+func TestKeptnEvaluationReconciler_fetchPreviousEvaluations(t *testing.T) {
+	now := time.Now()
+	current := makePreviousEvaluation("current", "3.0", apicommon.StatePending, time.Time{}, "")
+	current.Spec.PreviousVersion = "2.0"
+	oldest := makePreviousEvaluation("oldest", "0.9", apicommon.StateSucceeded, now.Add(-4*time.Hour), "50")
+	older := makePreviousEvaluation("older", "1.0", apicommon.StateSucceeded, now.Add(-2*time.Hour), "100")
+	older.Spec.PreviousVersion = "0.1"
+	failedOlder := makePreviousEvaluation("failed-older", "1.0", apicommon.StateFailed, now.Add(-90*time.Minute), "150")
+	failedOlder.Spec.PreviousVersion = "0.9"
+	newer := makePreviousEvaluation("newer", "2.0", apicommon.StateSucceeded, now.Add(-1*time.Hour), "200")
+	newer.Spec.PreviousVersion = "1.0"
+	otherVersion := makePreviousEvaluation("other-version", "2.1", apicommon.StateSucceeded, now, "300")
+	otherVersion.Spec.PreviousVersion = "2.0"
+	sameVersion := makePreviousEvaluation("same-version", "3.0", apicommon.StateSucceeded, now, "400")
+	preEval := makePreviousEvaluation("pre-eval", "2.0", apicommon.StateSucceeded, now, "500")
+	preEval.Spec.Type = apicommon.PreDeploymentEvaluationCheckType
+	otherDefinition := makePreviousEvaluation("other-definition", "2.0", apicommon.StateSucceeded, now, "600")
+	otherDefinition.Spec.EvaluationDefinition = "other"
+
+	client := fake.NewClientBuilder().
+		WithObjects(current, oldest, older, failedOlder, newer, otherVersion, sameVersion, preEval, otherDefinition).
+		WithIndex(&klcv1alpha2.KeptnEvaluation{}, comparisonIndex, indexComparisonKey).
+		Build()
+	r := &KeptnEvaluationReconciler{
+		Client: client,
+		Scheme: client.Scheme(),
+		Log:    testr.New(t),
+	}
+
+	relativeDefinition := &klcv1alpha2.KeptnEvaluationDefinition{
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1alpha2.Objective{{Name: "latency", EvaluationTarget: "<=+10%"}},
+		},
+	}
+	previous, err := r.fetchPreviousEvaluations(context.TODO(), current, relativeDefinition)
+	require.Nil(t, err)
+	// the chain of previous versions is 3.0 -> 2.0 -> 1.0 -> 0.9, the most recent evaluation of 1.0 failed
+	require.Len(t, previous, 3)
+	require.Equal(t, "newer", previous[0].Name)
+	require.Equal(t, "older", previous[1].Name)
+	require.Equal(t, "oldest", previous[2].Name)
+
+	absoluteDefinition := &klcv1alpha2.KeptnEvaluationDefinition{
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1alpha2.Objective{{Name: "latency", EvaluationTarget: "<100"}},
+		},
+	}
+	previous, err = r.fetchPreviousEvaluations(context.TODO(), current, absoluteDefinition)
+	require.Nil(t, err)
+	require.Empty(t, previous)
+}
+
+func TestComputeBaseline(t *testing.T) {
+	now := time.Now()
+	previous := []klcv1alpha2.KeptnEvaluation{
+		*makePreviousEvaluation("a", "3.0", apicommon.StateSucceeded, now, "100"),
+		*makePreviousEvaluation("b", "2.0", apicommon.StateSucceeded, now, "nan"),
+		*makePreviousEvaluation("c", "1.0", apicommon.StateSucceeded, now, "200"),
+		*makePreviousEvaluation("d", "0.1", apicommon.StateSucceeded, now, "600"),
+	}
+
+	tests := []struct {
+		name       string
+		comparison *klcv1alpha2.ObjectiveComparison
+		previous   []klcv1alpha2.KeptnEvaluation
+		want       float64
+		wantOk     bool
+	}{
+		{
+			name:     "previous version",
+			previous: previous,
+			want:     100,
+			wantOk:   true,
+		},
+		{
+			name:       "avg of last three, invalid values are skipped",
+			comparison: &klcv1alpha2.ObjectiveComparison{NumberOfResults: 3},
+			previous:   previous,
+			want:       300,
+			wantOk:     true,
+		},
+		{
+			name:       "median of last three",
+			comparison: &klcv1alpha2.ObjectiveComparison{NumberOfResults: 3, AggregateFunction: "median"},
+			previous:   previous,
+			want:       200,
+			wantOk:     true,
+		},
+		{
+			name:       "max of last two",
+			comparison: &klcv1alpha2.ObjectiveComparison{NumberOfResults: 2, AggregateFunction: "max"},
+			previous:   previous,
+			want:       200,
+			wantOk:     true,
+		},
+		{
+			name:   "no previous results",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objective := klcv1alpha2.Objective{Name: "latency", EvaluationTarget: "<=+10%", Comparison: tt.comparison}
+			got, ok := computeBaseline(objective, tt.previous)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckValueRelative(t *testing.T) {
+	objective := klcv1alpha2.Objective{EvaluationTarget: "<=+10% && <1000"}
+
+	check, err := checkValue(objective, &klcv1alpha2.EvaluationStatusItem{Value: "109", Baseline: "100"})
+	require.Nil(t, err)
+	require.True(t, check)
+
+	check, err = checkValue(objective, &klcv1alpha2.EvaluationStatusItem{Value: "111", Baseline: "100"})
+	require.Nil(t, err)
+	require.False(t, check)
+
+	check, err = checkValue(objective, &klcv1alpha2.EvaluationStatusItem{Value: "999"})
+	require.Nil(t, err)
+	require.True(t, check)
+
+	check, err = checkValue(objective, &klcv1alpha2.EvaluationStatusItem{Value: "1000"})
+	require.Nil(t, err)
+	require.False(t, check)
+}
@@ -0,0 +1,41 @@
+package keptnevaluation
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// runningEvaluations keeps the cancel functions of the evaluations that are currently queried,
+// so that the queries can be stopped as soon as an evaluation is deleted
+type runningEvaluations struct {
+	mtx     sync.Mutex
+	cancels map[types.NamespacedName]context.CancelFunc
+}
+
+// start returns a context that is cancelled when cancel is called for the evaluation,
+// the returned function must be called once the evaluation is done
+func (e *runningEvaluations) start(ctx context.Context, evaluation types.NamespacedName) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.cancels == nil {
+		e.cancels = make(map[types.NamespacedName]context.CancelFunc)
+	}
+	e.cancels[evaluation] = cancel
+	return ctx, func() {
+		e.mtx.Lock()
+		defer e.mtx.Unlock()
+		delete(e.cancels, evaluation)
+		cancel()
+	}
+}
+
+func (e *runningEvaluations) cancel(evaluation types.NamespacedName) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if cancel, ok := e.cancels[evaluation]; ok {
+		cancel()
+	}
+}
@@ -0,0 +1,136 @@
+package keptnevaluation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// blockingSLIProvider returns 1 for every query after delay and records the maximum number of concurrent queries
+type blockingSLIProvider struct {
+	delay   time.Duration
+	mtx     sync.Mutex
+	running int
+	max     int
+	calls   []time.Time
+}
+
+func (b *blockingSLIProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
+	b.mtx.Lock()
+	b.running++
+	if b.running > b.max {
+		b.max = b.running
+	}
+	b.calls = append(b.calls, time.Now())
+	b.mtx.Unlock()
+	defer func() {
+		b.mtx.Lock()
+		b.running--
+		b.mtx.Unlock()
+	}()
+
+	select {
+	case <-time.After(b.delay):
+		return "1", nil, nil
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+}
+
+func newConcurrencyTestDefinition(objectives int, maxConcurrency int) *klcv1alpha2.KeptnEvaluationDefinition {
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			MaxConcurrency: maxConcurrency,
+		},
+	}
+	for i := 0; i < objectives; i++ {
+		definition.Spec.Objectives = append(definition.Spec.Objectives, klcv1alpha2.Objective{
+			Name:             fmt.Sprintf("objective-%d", i),
+			Query:            "query",
+			EvaluationTarget: "<2",
+		})
+	}
+	return definition
+}
+
+func TestPerformEvaluation_Concurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxConcurrency int
+		wantMax        int
+	}{
+		{
+			name:    "default",
+			wantMax: 5,
+		},
+		{
+			name:           "sequential",
+			maxConcurrency: 1,
+			wantMax:        1,
+		},
+		{
+			name:           "more workers than objectives",
+			maxConcurrency: 20,
+			wantMax:        8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &KeptnEvaluationReconciler{Log: testr.New(t)}
+			provider := &blockingSLIProvider{delay: 20 * time.Millisecond}
+			evaluation := r.performEvaluation(context.TODO(), &klcv1alpha2.KeptnEvaluation{}, newConcurrencyTestDefinition(8, tt.maxConcurrency), provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, nil)
+
+			require.Equal(t, tt.wantMax, provider.max)
+			require.Len(t, provider.calls, 8)
+			require.Len(t, evaluation.Status.EvaluationStatus, 8)
+			require.Equal(t, apicommon.StateSucceeded, evaluation.Status.OverallStatus)
+		})
+	}
+}
+
+func TestPerformEvaluation_RateLimit(t *testing.T) {
+	r := &KeptnEvaluationReconciler{Log: testr.New(t)}
+	provider := &blockingSLIProvider{}
+	evaluationProvider := &klcv1alpha2.KeptnEvaluationProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "default"},
+		Spec: klcv1alpha2.KeptnEvaluationProviderSpec{
+			RateLimit: &klcv1alpha2.ProviderRateLimit{RequestsPerSecond: 20, Burst: 1},
+		},
+	}
+
+	start := time.Now()
+	evaluation := r.performEvaluation(context.TODO(), &klcv1alpha2.KeptnEvaluation{}, newConcurrencyTestDefinition(5, 5), provider, evaluationProvider, nil, nil)
+	require.Equal(t, apicommon.StateSucceeded, evaluation.Status.OverallStatus)
+	// the first query is sent right away, the others are spaced by 50ms
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestPerformEvaluation_Cancel(t *testing.T) {
+	r := &KeptnEvaluationReconciler{Log: testr.New(t)}
+	provider := &blockingSLIProvider{delay: time.Minute}
+	key := types.NamespacedName{Namespace: "default", Name: "my-evaluation"}
+
+	ctx, done := r.running.start(context.TODO(), key)
+	defer done()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		r.running.cancel(key)
+	}()
+
+	start := time.Now()
+	evaluation := r.performEvaluation(ctx, &klcv1alpha2.KeptnEvaluation{}, newConcurrencyTestDefinition(4, 2), provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, nil)
+	require.Less(t, time.Since(start), 10*time.Second)
+	require.Equal(t, apicommon.StateProgressing, evaluation.Status.OverallStatus)
+	for _, item := range evaluation.Status.EvaluationStatus {
+		require.Equal(t, apicommon.StateFailed, item.Status)
+	}
+}
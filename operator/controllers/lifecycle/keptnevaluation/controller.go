@@ -18,6 +18,10 @@ package keptnevaluation
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -31,13 +35,18 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // KeptnEvaluationReconciler reconciles a KeptnEvaluation object
@@ -48,6 +57,8 @@ type KeptnEvaluationReconciler struct {
 	Log      logr.Logger
 	Meters   apicommon.KeptnMeters
 	Tracer   trace.Tracer
+
+	running runningEvaluations
 }
 
 //clusterrole
@@ -56,6 +67,7 @@ type KeptnEvaluationReconciler struct {
 //+kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluations/finalizers,verbs=update
 //+kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluationproviders,verbs=get;list;watch
 //+kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptnevaluationdefinitions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch
 
 //role
 //+kubebuilder:rbac:groups=core,namespace=keptn-lifecycle-toolkit-system,resources=secrets,verbs=get
@@ -84,14 +96,33 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
+	if evaluation.Status.OverallStatus.IsCompleted() {
+		// the result of a finished evaluation, e.g. one that failed fast, must not be overwritten by another attempt
+		r.Log.Info("KeptnEvaluation has already finished")
+		return ctrl.Result{}, nil
+	}
+
 	ctx, span := r.setupEvaluationSpans(ctx, evaluation)
 	defer span.End()
 
-	if evaluation.Status.RetryCount >= evaluation.Spec.Retries {
+	r.setFailAction(ctx, evaluation)
+
+	if isEvaluationExceeded(evaluation) {
 		r.handleEvaluationExceededRetries(ctx, evaluation, span)
 		return ctrl.Result{}, nil
 	}
 
+	if evaluation.Status.RetryCount == 0 {
+		if delay := evaluation.Spec.InitialDelay.Duration - time.Since(evaluation.Status.StartTime.Time); delay > 0 {
+			// the start time is stored, so that the delay is not restarted with the next reconciliation
+			if err := r.Client.Status().Update(ctx, evaluation); err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return ctrl.Result{Requeue: true}, err
+			}
+			return ctrl.Result{Requeue: true, RequeueAfter: delay}, nil
+		}
+	}
+
 	if !evaluation.Status.OverallStatus.IsSucceeded() {
 		namespacedDefinition := types.NamespacedName{
 			Namespace: req.NamespacedName.Namespace,
@@ -109,7 +140,7 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			return ctrl.Result{}, nil
 		}
 		// load the provider
-		provider, err2 := providers.NewProvider(evaluationDefinition.Spec.Source, r.Log, r.Client)
+		provider, err2 := providers.NewProvider(evaluationProvider.GetType(), r.Log, r.Client)
 		if err2 != nil {
 			controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Error", evaluation, "ProviderNotFound", "evaluation provider was not found", "")
 			r.Log.Error(err2, "Failed to get the correct Metric Provider")
@@ -117,18 +148,49 @@ func (r *KeptnEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			return ctrl.Result{Requeue: false}, err2
 		}
 
-		evaluation = r.performEvaluation(ctx, evaluation, evaluationDefinition, provider, evaluationProvider)
+		previousEvaluations, err := r.fetchPreviousEvaluations(ctx, evaluation, evaluationDefinition)
+		if err != nil {
+			r.Log.Error(err, "Failed to retrieve previous evaluations")
+			span.SetStatus(codes.Error, err.Error())
+			return ctrl.Result{Requeue: true, RequeueAfter: r.getRetryInterval(evaluation)}, nil
+		}
 
+		// queries can read the outputs of the tasks that ran before the evaluation
+		outputs, err := controllercommon.GetTaskOutputs(ctx, r.Client, evaluation)
+		if err != nil {
+			r.Log.Error(err, "Failed to retrieve task outputs")
+			span.SetStatus(codes.Error, err.Error())
+			return ctrl.Result{Requeue: true, RequeueAfter: r.getRetryInterval(evaluation)}, nil
+		}
+
+		evalCtx, done := r.running.start(ctx, req.NamespacedName)
+		evaluation = r.performEvaluation(evalCtx, evaluation, evaluationDefinition, provider, evaluationProvider, previousEvaluations, outputs)
+		deleted := evalCtx.Err() != nil && ctx.Err() == nil
+		done()
+		if deleted {
+			r.Log.Info("KeptnEvaluation was deleted while it was evaluated, discarding the results")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if !evaluation.Status.OverallStatus.IsSucceeded() &&
+		evaluation.Status.FailAction == klcv1alpha2.FailActionFail && hasViolatedObjective(evaluation) {
+		r.handleEvaluationViolated(ctx, evaluation, span)
+		return ctrl.Result{}, nil
 	}
 
 	if !evaluation.Status.OverallStatus.IsSucceeded() {
 		if err := r.handleEvaluationIncomplete(ctx, evaluation, span); err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
-		return ctrl.Result{Requeue: true, RequeueAfter: evaluation.Spec.RetryInterval.Duration}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: r.getRetryInterval(evaluation)}, nil
 	}
 
 	r.Log.Info("Finished Reconciling KeptnEvaluation")
+	if evaluation.Status.Warning {
+		controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "SucceededWithWarning", "evaluation reached warning score "+evaluation.Status.Score, "")
+		span.AddEvent("evaluation succeeded with warning, score: " + evaluation.Status.Score)
+	}
 
 	err := r.updateFinishedEvaluationMetrics(ctx, evaluation, span)
 
@@ -146,6 +208,18 @@ func (r *KeptnEvaluationReconciler) setupEvaluationSpans(ctx context.Context, ev
 	return ctx, span
 }
 
+// getRetryInterval returns the time until the next attempt, which ends early when the timeout of the evaluation is reached
+func (r *KeptnEvaluationReconciler) getRetryInterval(evaluation *klcv1alpha2.KeptnEvaluation) time.Duration {
+	//nolint:gosec // the jitter does not need a cryptographically secure random number
+	interval := nextRetryInterval(evaluation.Spec, evaluation.Status.RetryCount, rand.Float64())
+	if evaluation.Spec.Timeout.Duration > 0 {
+		if remaining := evaluation.Spec.Timeout.Duration - time.Since(evaluation.Status.StartTime.Time); remaining < interval {
+			interval = remaining
+		}
+	}
+	return interval
+}
+
 func (r *KeptnEvaluationReconciler) handleEvaluationIncomplete(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, span trace.Span) error {
 	// Evaluation is uncompleted, update status anyway this avoids updating twice in case of completion
 	err := r.Client.Status().Update(ctx, evaluation)
@@ -162,31 +236,110 @@ func (r *KeptnEvaluationReconciler) handleEvaluationIncomplete(ctx context.Conte
 }
 
 func (r *KeptnEvaluationReconciler) handleEvaluationExceededRetries(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, span trace.Span) {
-	controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "ReconcileTimeOut", "retryCount exceeded", "")
-	err := controllererrors.ErrRetryCountExceeded
-	span.SetStatus(codes.Error, err.Error())
-	evaluation.Status.OverallStatus = apicommon.StateFailed
+	reason := "retryCount exceeded"
+	if evaluation.Spec.Timeout.Duration > 0 {
+		reason = "timeout exceeded"
+	}
+	controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "ReconcileTimeOut", reason, "")
+	if evaluation.Status.FailAction == klcv1alpha2.FailActionWarn {
+		// the evaluation failed, but the user asked to only be warned about it
+		controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "SucceededWithWarning", "evaluation failed with score "+evaluation.Status.Score+", failAction is warn", "")
+		span.AddEvent("evaluation failed with score " + evaluation.Status.Score + ", failAction is warn")
+		evaluation.Status.Warning = true
+		evaluation.Status.OverallStatus = apicommon.StateSucceeded
+	} else {
+		err := controllererrors.ErrRetryCountExceeded
+		if evaluation.Spec.Timeout.Duration > 0 {
+			err = controllererrors.ErrEvaluationTimeoutExceeded
+		}
+		span.SetStatus(codes.Error, err.Error())
+		evaluation.Status.OverallStatus = apicommon.StateFailed
+	}
 	err2 := r.updateFinishedEvaluationMetrics(ctx, evaluation, span)
 	if err2 != nil {
 		r.Log.Error(err2, "failed to update finished evaluation metrics")
 	}
 }
 
-func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, evaluationDefinition *klcv1alpha2.KeptnEvaluationDefinition, provider providers.KeptnSLIProvider, evaluationProvider *klcv1alpha2.KeptnEvaluationProvider) *klcv1alpha2.KeptnEvaluation {
-	statusSummary := apicommon.StatusSummary{Total: len(evaluationDefinition.Spec.Objectives)}
+// setFailAction reads the fail action of the evaluation's definition on every reconciliation,
+// so that the definition may be created or changed after the evaluation
+func (r *KeptnEvaluationReconciler) setFailAction(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation) {
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: evaluation.Namespace, Name: evaluation.Spec.EvaluationDefinition}, definition)
+	if err != nil && !errors.IsNotFound(err) {
+		r.Log.Error(err, "Failed to retrieve the fail action of the KeptnEvaluationDefinition")
+		return
+	}
+	evaluation.Status.FailAction = evaluation.GetFailAction(definition.Spec)
+}
+
+// handleEvaluationViolated fails the evaluation without further retries, it is used for the fail action fail
+func (r *KeptnEvaluationReconciler) handleEvaluationViolated(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, span trace.Span) {
+	controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileEvaluation, "Warning", evaluation, "FailedFast", "an objective was violated, failAction is fail", "")
+	span.SetStatus(codes.Error, "an objective was violated")
+	evaluation.Status.OverallStatus = apicommon.StateFailed
+	if err := r.updateFinishedEvaluationMetrics(ctx, evaluation, span); err != nil {
+		r.Log.Error(err, "failed to update finished evaluation metrics")
+	}
+}
+
+func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, evaluationDefinition *klcv1alpha2.KeptnEvaluationDefinition, provider providers.KeptnSLIProvider, evaluationProvider *klcv1alpha2.KeptnEvaluationProvider, previousEvaluations []klcv1alpha2.KeptnEvaluation, outputs map[string]map[string]string) *klcv1alpha2.KeptnEvaluation {
 	newStatus := make(map[string]klcv1alpha2.EvaluationStatusItem)
+	queryContext := evaluation.GetQueryContext()
+	queryContext.Outputs = outputs
 
 	if evaluation.Status.EvaluationStatus == nil {
 		evaluation.Status.EvaluationStatus = make(map[string]klcv1alpha2.EvaluationStatusItem)
 	}
 
+	// objectives that already succeeded are not queried again
+	var pending []klcv1alpha2.Objective
 	for _, query := range evaluationDefinition.Spec.Objectives {
-		newStatus, statusSummary = r.evaluateObjective(ctx, evaluation, statusSummary, newStatus, query, provider, evaluationProvider)
+		if _, ok := evaluation.Status.EvaluationStatus[query.Name]; !ok {
+			evaluation.AddEvaluationStatus(query)
+		}
+		if evaluation.Status.EvaluationStatus[query.Name].Status.IsSucceeded() {
+			newStatus[query.Name] = evaluation.Status.EvaluationStatus[query.Name]
+			continue
+		}
+		pending = append(pending, query)
 	}
 
+	// the objectives are queried by a bounded number of workers, so that slow providers do not block each other
+	queries := make(chan klcv1alpha2.Objective)
+	mtx := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	workers := evaluationDefinition.Spec.GetMaxConcurrency()
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for query := range queries {
+				statusItem := r.evaluateObjective(ctx, evaluation, queryContext, query, provider, evaluationProvider, previousEvaluations)
+				mtx.Lock()
+				newStatus[query.Name] = statusItem
+				mtx.Unlock()
+			}
+		}()
+	}
+	for _, query := range pending {
+		queries <- query
+	}
+	close(queries)
+	wg.Wait()
+
 	evaluation.Status.RetryCount++
 	evaluation.Status.EvaluationStatus = newStatus
-	if apicommon.GetOverallState(statusSummary) == apicommon.StateSucceeded {
+
+	score := computeScore(evaluationDefinition.Spec, newStatus)
+	evaluation.Status.Score = fmt.Sprintf("%.2f", score.Percentage)
+	evaluation.Status.Warning = score.Warning
+	evaluation.Status.History = appendAttempt(evaluation.Status.History, newAttempt(evaluation, evaluationDefinition.Spec.Objectives))
+	// like the pass criteria, the warning criteria finish the evaluation, it is not retried to reach a better score
+	if score.Pass || score.Warning {
 		evaluation.Status.OverallStatus = apicommon.StateSucceeded
 	} else {
 		evaluation.Status.OverallStatus = apicommon.StateProgressing
@@ -195,25 +348,36 @@ func (r *KeptnEvaluationReconciler) performEvaluation(ctx context.Context, evalu
 	return evaluation
 }
 
-func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, statusSummary apicommon.StatusSummary, newStatus map[string]klcv1alpha2.EvaluationStatusItem, query klcv1alpha2.Objective, provider providers.KeptnSLIProvider, evaluationProvider *klcv1alpha2.KeptnEvaluationProvider) (map[string]klcv1alpha2.EvaluationStatusItem, apicommon.StatusSummary) {
-	if _, ok := evaluation.Status.EvaluationStatus[query.Name]; !ok {
-		evaluation.AddEvaluationStatus(query)
-	}
-	if evaluation.Status.EvaluationStatus[query.Name].Status.IsSucceeded() {
-		statusSummary = apicommon.UpdateStatusSummary(apicommon.StateSucceeded, statusSummary)
-		newStatus[query.Name] = evaluation.Status.EvaluationStatus[query.Name]
-		return newStatus, statusSummary
+// evaluateObjective queries and checks a single objective, it is called concurrently and must not modify the evaluation
+func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, queryContext apicommon.QueryContext, query klcv1alpha2.Objective, provider providers.KeptnSLIProvider, evaluationProvider *klcv1alpha2.KeptnEvaluationProvider, previousEvaluations []klcv1alpha2.KeptnEvaluation) klcv1alpha2.EvaluationStatusItem {
+	statusItem := &klcv1alpha2.EvaluationStatusItem{
+		Status:  apicommon.StateFailed,
+		Attempt: evaluation.Status.RetryCount + 1,
 	}
 	// resolving the SLI value
-	value, _, err := provider.EvaluateQuery(ctx, query, *evaluationProvider)
-	statusItem := &klcv1alpha2.EvaluationStatusItem{
-		Value:  value,
-		Status: apicommon.StateFailed,
+	var err error
+	query.Query, err = apicommon.ResolveQuery(query.Query, queryContext)
+	if err == nil {
+		statusItem.Query = query.Query
+		err = providers.WaitForRateLimit(ctx, *evaluationProvider)
+	}
+	if err == nil {
+		var rawValue []byte
+		statusItem.QueryTime = metav1.Now()
+		statusItem.Value, rawValue, err = provider.EvaluateQuery(ctx, query, *evaluationProvider)
+		statusItem.RawValue = cupRawValue(rawValue)
 	}
 	if err != nil {
 		statusItem.Message = err.Error()
 		statusItem.Status = apicommon.StateFailed
 	}
+	if isRelativeObjective(query) {
+		if baseline, ok := computeBaseline(query, previousEvaluations); ok {
+			statusItem.Baseline = strconv.FormatFloat(baseline, 'f', -1, 64)
+		} else if err == nil {
+			statusItem.Message = "no previous results to compare with, relative targets were not checked"
+		}
+	}
 	// Evaluating SLO
 	check, err := checkValue(query, statusItem)
 	if err != nil {
@@ -222,11 +386,10 @@ func (r *KeptnEvaluationReconciler) evaluateObjective(ctx context.Context, evalu
 	}
 	if check {
 		statusItem.Status = apicommon.StateSucceeded
+	} else if warning, _ := checkWarning(query, statusItem); warning {
+		statusItem.Status = apicommon.StateWarning
 	}
-	statusSummary = apicommon.UpdateStatusSummary(statusItem.Status, statusSummary)
-	newStatus[query.Name] = *statusItem
-
-	return newStatus, statusSummary
+	return *statusItem
 }
 
 func (r *KeptnEvaluationReconciler) updateFinishedEvaluationMetrics(ctx context.Context, evaluation *klcv1alpha2.KeptnEvaluation, span trace.Span) error {
@@ -254,8 +417,30 @@ func (r *KeptnEvaluationReconciler) updateFinishedEvaluationMetrics(ctx context.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KeptnEvaluationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &klcv1alpha2.KeptnEvaluation{}, comparisonIndex, indexComparisonKey); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&klcv1alpha2.KeptnEvaluation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&klcv1alpha2.KeptnEvaluation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}, predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				if !e.ObjectNew.GetDeletionTimestamp().IsZero() {
+					r.running.cancel(client.ObjectKeyFromObject(e.ObjectNew))
+				}
+				return true
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				// the queries of a deleted evaluation are stopped right away instead of waiting for them to finish
+				r.running.cancel(client.ObjectKeyFromObject(e.Object))
+				return true
+			},
+		})).
+		// the cached connections, tokens and rate limiters of a provider are dropped once it is deleted,
+		// the provider changes themselves are picked up by the next query, so no evaluation is enqueued
+		Watches(&source.Kind{Type: &klcv1alpha2.KeptnEvaluationProvider{}}, handler.Funcs{
+			DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+				providers.EvictProvider(client.ObjectKeyFromObject(e.Object))
+			},
+		}).
 		Complete(r)
 }
 
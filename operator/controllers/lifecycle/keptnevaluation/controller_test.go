@@ -2,15 +2,24 @@ package keptnevaluation
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/testr"
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	metricsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/metrics/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/fake"
 	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/providers"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestKeptnEvaluationReconciler_fetchDefinitionAndProvider(t *testing.T) {
@@ -175,3 +184,456 @@ func setupProviders() (*klcv1alpha2.KeptnEvaluationProvider, *klcv1alpha2.KeptnE
 
 	return DTProv, PromProv
 }
+
+type fakeSLIProvider struct {
+	values map[string]string
+}
+
+func (f *fakeSLIProvider) EvaluateQuery(ctx context.Context, objective klcv1alpha2.Objective, provider klcv1alpha2.KeptnEvaluationProvider) (string, []byte, error) {
+	value, ok := f.values[objective.Query]
+	if !ok {
+		return "", []byte("no data"), fmt.Errorf("unknown query %s", objective.Query)
+	}
+	return value, []byte("raw " + value), nil
+}
+
+func TestKeptnEvaluationReconciler_performEvaluation(t *testing.T) {
+	r := &KeptnEvaluationReconciler{
+		Log: testr.New(t),
+	}
+	evaluation := &klcv1alpha2.KeptnEvaluation{
+		Spec: klcv1alpha2.KeptnEvaluationSpec{
+			Workload:        "my-workload",
+			WorkloadVersion: "1.0",
+		},
+	}
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1alpha2.Objective{
+				{Name: "latency", Query: "latency{workload=\"{{.Workload}}\"}", EvaluationTarget: "<100"},
+				{Name: "errors", Query: "errors", EvaluationTarget: "<1"},
+				{Name: "saturation", Query: "saturation", EvaluationTarget: "<1"},
+			},
+		},
+	}
+	provider := &fakeSLIProvider{values: map[string]string{
+		"latency{workload=\"my-workload\"}": "50",
+		"errors":                            "2",
+	}}
+
+	evaluation = r.performEvaluation(context.TODO(), evaluation, definition, provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, nil)
+
+	latency := evaluation.Status.EvaluationStatus["latency"]
+	require.Equal(t, apicommon.StateSucceeded, latency.Status)
+	require.Equal(t, "latency{workload=\"my-workload\"}", latency.Query)
+	require.Equal(t, []byte("raw 50"), latency.RawValue)
+	require.Equal(t, 1, latency.Attempt)
+	require.False(t, latency.QueryTime.IsZero())
+	require.Equal(t, apicommon.StateFailed, evaluation.Status.EvaluationStatus["errors"].Status)
+	// the response of a failed query is kept
+	saturation := evaluation.Status.EvaluationStatus["saturation"]
+	require.Equal(t, apicommon.StateFailed, saturation.Status)
+	require.Equal(t, []byte("no data"), saturation.RawValue)
+
+	// succeeded objectives are not queried again
+	provider.values["errors"] = "0"
+	provider.values["saturation"] = "0"
+	evaluation = r.performEvaluation(context.TODO(), evaluation, definition, provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, nil)
+	require.Equal(t, 1, evaluation.Status.EvaluationStatus["latency"].Attempt)
+	require.Equal(t, 2, evaluation.Status.EvaluationStatus["errors"].Attempt)
+	require.Equal(t, apicommon.StateSucceeded, evaluation.Status.OverallStatus)
+
+	require.Len(t, evaluation.Status.History, 2)
+	require.Equal(t, 1, evaluation.Status.History[0].Attempt)
+	require.Equal(t, "33.33", evaluation.Status.History[0].Score)
+	require.Equal(t, []klcv1alpha2.ObjectiveResult{
+		{Name: "latency", Value: "50", Status: apicommon.StateSucceeded},
+		{Name: "errors", Value: "2", Status: apicommon.StateFailed},
+		{Name: "saturation", Status: apicommon.StateFailed},
+	}, evaluation.Status.History[0].Objectives)
+	require.Equal(t, 2, evaluation.Status.History[1].Attempt)
+	require.Equal(t, "100.00", evaluation.Status.History[1].Score)
+}
+
+func TestKeptnEvaluationReconciler_performEvaluationTaskOutputs(t *testing.T) {
+	r := &KeptnEvaluationReconciler{
+		Log: testr.New(t),
+	}
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Objectives: []klcv1alpha2.Objective{
+				{Name: "canary-errors", Query: "errors{weight=\"{{.Outputs.canary.weight}}\"}", EvaluationTarget: "<1"},
+			},
+		},
+	}
+	provider := &fakeSLIProvider{values: map[string]string{
+		"errors{weight=\"10\"}": "0",
+	}}
+
+	outputs := map[string]map[string]string{"canary": {"weight": "10"}}
+	evaluation := r.performEvaluation(context.TODO(), &klcv1alpha2.KeptnEvaluation{}, definition, provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, outputs)
+	require.Equal(t, "errors{weight=\"10\"}", evaluation.Status.EvaluationStatus["canary-errors"].Query)
+	require.Equal(t, apicommon.StateSucceeded, evaluation.Status.OverallStatus)
+
+	// the query fails as long as the task has no output
+	evaluation = r.performEvaluation(context.TODO(), &klcv1alpha2.KeptnEvaluation{}, definition, provider, &klcv1alpha2.KeptnEvaluationProvider{}, nil, nil)
+	require.Equal(t, apicommon.StateFailed, evaluation.Status.EvaluationStatus["canary-errors"].Status)
+	require.Empty(t, evaluation.Status.EvaluationStatus["canary-errors"].Query)
+}
+
+func newTestReconciler(t *testing.T, objects ...client.Object) (*KeptnEvaluationReconciler, client.Client, *record.FakeRecorder) {
+	k8sClient := fake.NewClient(objects...)
+	recorder := record.NewFakeRecorder(100)
+	meter := metric.NewMeterProvider().Meter("keptn/evaluation")
+	evaluationCount, _ := meter.SyncInt64().Counter("keptn.evaluation.count")
+	evaluationDuration, _ := meter.SyncFloat64().Histogram("keptn.evaluation.duration")
+	r := &KeptnEvaluationReconciler{
+		Client:   k8sClient,
+		Scheme:   k8sClient.Scheme(),
+		Recorder: recorder,
+		Log:      testr.New(t),
+		Tracer:   trace.NewNoopTracerProvider().Tracer("tracer"),
+		Meters: apicommon.KeptnMeters{
+			EvaluationCount:    evaluationCount,
+			EvaluationDuration: evaluationDuration,
+		},
+	}
+	return r, k8sClient, recorder
+}
+
+func TestKeptnEvaluationReconciler_FailAction(t *testing.T) {
+	tests := []struct {
+		name             string
+		failAction       string
+		definitionAction string
+		metricValue      string
+		retryCount       int
+		wantStatus       apicommon.KeptnState
+		wantWarning      bool
+		wantEvent        string
+	}{
+		{
+			name:        "retried without fail action",
+			metricValue: "10",
+			wantStatus:  apicommon.StateProgressing,
+			wantEvent:   "NotFinished",
+		},
+		{
+			name:        "fail stops at violated objective",
+			failAction:  klcv1alpha2.FailActionFail,
+			metricValue: "10",
+			wantStatus:  apicommon.StateFailed,
+			wantEvent:   "FailedFast",
+		},
+		{
+			name:       "fail retries failed queries",
+			failAction: klcv1alpha2.FailActionFail,
+			wantStatus: apicommon.StateProgressing,
+			wantEvent:  "NotFinished",
+		},
+		{
+			name:        "warn succeeds with warning after retries",
+			failAction:  klcv1alpha2.FailActionWarn,
+			metricValue: "10",
+			retryCount:  3,
+			wantStatus:  apicommon.StateSucceeded,
+			wantWarning: true,
+			wantEvent:   "SucceededWithWarning",
+		},
+		{
+			name:             "fail action of the definition applies",
+			definitionAction: klcv1alpha2.FailActionFail,
+			metricValue:      "10",
+			wantStatus:       apicommon.StateFailed,
+			wantEvent:        "FailedFast",
+		},
+		{
+			name:             "fail action of the evaluation overrides the definition",
+			failAction:       klcv1alpha2.FailActionWarn,
+			definitionAction: klcv1alpha2.FailActionFail,
+			metricValue:      "10",
+			retryCount:       3,
+			wantStatus:       apicommon.StateSucceeded,
+			wantWarning:      true,
+			wantEvent:        "SucceededWithWarning",
+		},
+		{
+			name:        "ignore fails after retries",
+			failAction:  klcv1alpha2.FailActionIgnore,
+			metricValue: "10",
+			retryCount:  3,
+			wantStatus:  apicommon.StateFailed,
+			wantEvent:   "ReconcileTimeOut",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := &klcv1alpha2.KeptnEvaluationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-definition", Namespace: providers.KLTNamespace},
+				Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+					Source: providers.KeptnMetricProviderName,
+					Objectives: []klcv1alpha2.Objective{
+						{Name: "my-metric", Query: "my-metric", EvaluationTarget: "<5"},
+					},
+					FailAction: tt.definitionAction,
+				},
+			}
+			evaluation := &klcv1alpha2.KeptnEvaluation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-evaluation", Namespace: providers.KLTNamespace},
+				Spec: klcv1alpha2.KeptnEvaluationSpec{
+					Workload:             "my-workload",
+					WorkloadVersion:      "1.0",
+					EvaluationDefinition: "my-definition",
+					Retries:              3,
+					FailAction:           tt.failAction,
+				},
+				Status: klcv1alpha2.KeptnEvaluationStatus{
+					RetryCount:    tt.retryCount,
+					OverallStatus: apicommon.StatePending,
+				},
+			}
+			objects := []client.Object{definition, evaluation}
+			if tt.metricValue != "" {
+				objects = append(objects, &metricsv1alpha1.KeptnMetric{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-metric", Namespace: providers.KLTNamespace},
+					Status:     metricsv1alpha1.KeptnMetricStatus{Value: tt.metricValue},
+				})
+			}
+			r, k8sClient, recorder := newTestReconciler(t, objects...)
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}})
+			require.Nil(t, err)
+
+			result := &klcv1alpha2.KeptnEvaluation{}
+			require.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}, result))
+			require.Equal(t, tt.wantStatus, result.Status.OverallStatus)
+			require.Equal(t, tt.wantWarning, result.Status.Warning)
+			close(recorder.Events)
+			events := ""
+			for event := range recorder.Events {
+				events += event + "\n"
+			}
+			require.Contains(t, events, tt.wantEvent)
+		})
+	}
+}
+
+func TestKeptnEvaluationReconciler_FailActionReconciledTwice(t *testing.T) {
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-definition", Namespace: providers.KLTNamespace},
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Source: providers.KeptnMetricProviderName,
+			Objectives: []klcv1alpha2.Objective{
+				{Name: "my-metric", Query: "my-metric", EvaluationTarget: "<5"},
+			},
+		},
+	}
+	evaluation := &klcv1alpha2.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-evaluation", Namespace: providers.KLTNamespace},
+		Spec: klcv1alpha2.KeptnEvaluationSpec{
+			Workload:             "my-workload",
+			WorkloadVersion:      "1.0",
+			EvaluationDefinition: "my-definition",
+			Retries:              3,
+			FailAction:           klcv1alpha2.FailActionFail,
+		},
+		Status: klcv1alpha2.KeptnEvaluationStatus{
+			OverallStatus: apicommon.StatePending,
+		},
+	}
+	metric := &metricsv1alpha1.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-metric", Namespace: providers.KLTNamespace},
+		Status:     metricsv1alpha1.KeptnMetricStatus{Value: "10"},
+	}
+	r, k8sClient, recorder := newTestReconciler(t, definition, evaluation, metric)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}}
+
+	_, err := r.Reconcile(context.TODO(), request)
+	require.Nil(t, err)
+
+	stored := &klcv1alpha2.KeptnEvaluation{}
+	require.Nil(t, k8sClient.Get(context.TODO(), request.NamespacedName, stored))
+	require.Equal(t, apicommon.StateFailed, stored.Status.OverallStatus)
+	require.Equal(t, 1, stored.Status.RetryCount)
+
+	// the metric would pass now, but the evaluation has already failed fast
+	require.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-metric"}, metric))
+	metric.Status.Value = "1"
+	require.Nil(t, k8sClient.Status().Update(context.TODO(), metric))
+	<-recorder.Events
+
+	result, err := r.Reconcile(context.TODO(), request)
+	require.Nil(t, err)
+	require.Equal(t, ctrl.Result{}, result)
+
+	require.Nil(t, k8sClient.Get(context.TODO(), request.NamespacedName, stored))
+	require.Equal(t, apicommon.StateFailed, stored.Status.OverallStatus)
+	require.Equal(t, 1, stored.Status.RetryCount)
+	require.Equal(t, "10", stored.Status.EvaluationStatus["my-metric"].Value)
+	require.Empty(t, recorder.Events)
+}
+
+func TestKeptnEvaluationReconciler_WarningIsFinal(t *testing.T) {
+	definition := &klcv1alpha2.KeptnEvaluationDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-definition", Namespace: providers.KLTNamespace},
+		Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+			Source: providers.KeptnMetricProviderName,
+			Objectives: []klcv1alpha2.Objective{
+				{Name: "my-metric", Query: "my-metric", EvaluationTarget: "<5", WarningTarget: "<20"},
+			},
+			TotalScore: klcv1alpha2.TotalScore{PassPercentage: 100, WarningPercentage: 50},
+		},
+	}
+	evaluation := &klcv1alpha2.KeptnEvaluation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-evaluation", Namespace: providers.KLTNamespace},
+		Spec: klcv1alpha2.KeptnEvaluationSpec{
+			Workload:             "my-workload",
+			WorkloadVersion:      "1.0",
+			EvaluationDefinition: "my-definition",
+			Retries:              3,
+		},
+		Status: klcv1alpha2.KeptnEvaluationStatus{
+			OverallStatus: apicommon.StatePending,
+		},
+	}
+	metric := &metricsv1alpha1.KeptnMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-metric", Namespace: providers.KLTNamespace},
+		Status:     metricsv1alpha1.KeptnMetricStatus{Value: "10"},
+	}
+	r, k8sClient, recorder := newTestReconciler(t, definition, evaluation, metric)
+
+	// the first attempt reaches the warning criteria, the remaining retries are not used
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}})
+	require.Nil(t, err)
+	require.False(t, result.Requeue)
+
+	got := &klcv1alpha2.KeptnEvaluation{}
+	require.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}, got))
+	require.Equal(t, apicommon.StateSucceeded, got.Status.OverallStatus)
+	require.True(t, got.Status.Warning)
+	require.Equal(t, 1, got.Status.RetryCount)
+	require.Equal(t, "50.00", got.Status.Score)
+	require.Contains(t, <-recorder.Events, "SucceededWithWarning")
+}
+
+func TestHasViolatedObjective(t *testing.T) {
+	evaluation := &klcv1alpha2.KeptnEvaluation{
+		Status: klcv1alpha2.KeptnEvaluationStatus{
+			EvaluationStatus: map[string]klcv1alpha2.EvaluationStatusItem{
+				"passed":       {Value: "1", Status: apicommon.StateSucceeded},
+				"query failed": {Status: apicommon.StateFailed, Message: "timeout"},
+			},
+		},
+	}
+	require.False(t, hasViolatedObjective(evaluation))
+
+	evaluation.Status.EvaluationStatus["violated"] = klcv1alpha2.EvaluationStatusItem{Value: "10", Status: apicommon.StateFailed}
+	require.True(t, hasViolatedObjective(evaluation))
+}
+
+func TestKeptnEvaluationReconciler_Timing(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          klcv1alpha2.KeptnEvaluationSpec
+		status        apicommon.KeptnState
+		startedBefore time.Duration
+		wantStatus    apicommon.KeptnState
+		wantRetries   int
+		wantRequeue   time.Duration
+	}{
+		{
+			name: "initial delay",
+			spec: klcv1alpha2.KeptnEvaluationSpec{
+				Retries:      3,
+				InitialDelay: metav1.Duration{Duration: time.Minute},
+			},
+			wantStatus:  apicommon.StatePending,
+			wantRequeue: time.Minute,
+		},
+		{
+			name: "initial delay passed",
+			spec: klcv1alpha2.KeptnEvaluationSpec{
+				Retries:       3,
+				RetryInterval: metav1.Duration{Duration: 5 * time.Second},
+				InitialDelay:  metav1.Duration{Duration: time.Minute},
+			},
+			startedBefore: 2 * time.Minute,
+			wantStatus:    apicommon.StateProgressing,
+			wantRetries:   1,
+			wantRequeue:   5 * time.Second,
+		},
+		{
+			name: "retry interval capped by timeout",
+			spec: klcv1alpha2.KeptnEvaluationSpec{
+				RetryInterval: metav1.Duration{Duration: time.Minute},
+				Timeout:       metav1.Duration{Duration: time.Minute},
+			},
+			startedBefore: 30 * time.Second,
+			wantStatus:    apicommon.StateProgressing,
+			wantRetries:   1,
+			wantRequeue:   30 * time.Second,
+		},
+		{
+			name: "timeout used instead of retries",
+			spec: klcv1alpha2.KeptnEvaluationSpec{
+				Timeout: metav1.Duration{Duration: time.Minute},
+			},
+			startedBefore: 2 * time.Minute,
+			wantStatus:    apicommon.StateFailed,
+		},
+		{
+			name: "succeeded evaluation is not timed out later",
+			spec: klcv1alpha2.KeptnEvaluationSpec{
+				Timeout: metav1.Duration{Duration: time.Minute},
+			},
+			status:        apicommon.StateSucceeded,
+			startedBefore: 2 * time.Minute,
+			wantStatus:    apicommon.StateSucceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := &klcv1alpha2.KeptnEvaluationDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-definition", Namespace: providers.KLTNamespace},
+				Spec: klcv1alpha2.KeptnEvaluationDefinitionSpec{
+					Source: providers.KeptnMetricProviderName,
+					Objectives: []klcv1alpha2.Objective{
+						{Name: "my-metric", Query: "my-metric", EvaluationTarget: "<5"},
+					},
+				},
+			}
+			tt.spec.EvaluationDefinition = "my-definition"
+			evaluation := &klcv1alpha2.KeptnEvaluation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-evaluation", Namespace: providers.KLTNamespace},
+				Spec:       tt.spec,
+				Status: klcv1alpha2.KeptnEvaluationStatus{
+					OverallStatus: apicommon.StatePending,
+				},
+			}
+			if tt.status != "" {
+				evaluation.Status.OverallStatus = tt.status
+			}
+			if tt.startedBefore > 0 {
+				evaluation.Status.StartTime = metav1.NewTime(time.Now().Add(-tt.startedBefore))
+			}
+			metric := &metricsv1alpha1.KeptnMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-metric", Namespace: providers.KLTNamespace},
+				Status:     metricsv1alpha1.KeptnMetricStatus{Value: "10"},
+			}
+			r, k8sClient, recorder := newTestReconciler(t, definition, evaluation, metric)
+
+			result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}})
+			require.Nil(t, err)
+			require.InDelta(t, tt.wantRequeue, result.RequeueAfter, float64(time.Second))
+
+			stored := &klcv1alpha2.KeptnEvaluation{}
+			require.Nil(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: providers.KLTNamespace, Name: "my-evaluation"}, stored))
+			require.Equal(t, tt.wantStatus, stored.Status.OverallStatus)
+			require.Equal(t, tt.wantRetries, stored.Status.RetryCount)
+			require.False(t, stored.Status.StartTime.IsZero())
+			if tt.status.IsCompleted() {
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
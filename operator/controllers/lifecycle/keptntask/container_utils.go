@@ -0,0 +1,34 @@
+package keptntask
+
+import (
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (r *KeptnTaskReconciler) generateContainerJob(task *klcv1alpha2.KeptnTask, spec klcv1alpha2.ContainerSpec, taskContext klcv1alpha2.TaskContext) (*batchv1.Job, error) {
+	job := r.newTaskJob(task)
+
+	envVars, err := getTaskEnvVars(task.Spec.Parameters.Inline, taskContext, task.Spec.SecureParameters.Secret)
+	if err != nil {
+		return job, err
+	}
+
+	job.Spec.Template.Spec.ServiceAccountName = spec.ServiceAccountName
+	job.Spec.Template.Spec.Volumes = spec.Volumes
+	job.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:            "keptn-task",
+			Image:           spec.Image,
+			ImagePullPolicy: spec.ImagePullPolicy,
+			Command:         spec.Command,
+			Args:            spec.Args,
+			// the variables of the definition come last, so that they can refer to the ones of Keptn
+			Env:          append(envVars, spec.Env...),
+			EnvFrom:      spec.EnvFrom,
+			Resources:    spec.Resources,
+			VolumeMounts: spec.VolumeMounts,
+		},
+	}
+	return job, nil
+}
@@ -0,0 +1,167 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/options/v1alpha1"
+	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeptnTaskReconciler_createContainerJob(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+
+	fakeClient := fake.NewClientBuilder().Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+
+	taskDefinition := makeTaskDefinitionWithContainer(taskDefinitionName, namespace)
+	err = fakeClient.Create(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	r := &KeptnTaskReconciler{
+		Client:   fakeClient,
+		Recorder: record.NewFakeRecorder(100),
+		Log:      ctrl.Log.WithName("task-controller"),
+		Scheme:   fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	task.Spec.Parameters.Inline = map[string]string{"foo": "bar"}
+	task.Spec.SecureParameters.Secret = "my-secret"
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	err = r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+	require.NotEmpty(t, task.Status.JobName)
+
+	job := &batchv1.Job{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.JobName}, job)
+	require.Nil(t, err)
+
+	require.NotEmpty(t, job.OwnerReferences)
+	require.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	require.Nil(t, job.Spec.ActiveDeadlineSeconds)
+
+	podSpec := job.Spec.Template.Spec
+	require.Equal(t, v1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Equal(t, "my-service-account", podSpec.ServiceAccountName)
+	require.Equal(t, taskDefinition.Spec.Container.Volumes, podSpec.Volumes)
+	require.Len(t, podSpec.Containers, 1)
+
+	container := podSpec.Containers[0]
+	require.Equal(t, "busybox:1.36", container.Image)
+	require.Equal(t, []string{"sh", "-c"}, container.Command)
+	require.Equal(t, []string{"echo $GREETING"}, container.Args)
+	require.Equal(t, taskDefinition.Spec.Container.Resources, container.Resources)
+	require.Equal(t, taskDefinition.Spec.Container.VolumeMounts, container.VolumeMounts)
+
+	envNames := []string{}
+	for _, env := range container.Env {
+		envNames = append(envNames, env.Name)
+	}
+	require.Equal(t, []string{"DATA", "CONTEXT", "SECURE_DATA", "GREETING"}, envNames)
+	require.Equal(t, `{"foo":"bar"}`, container.Env[0].Value)
+}
+
+func TestKeptnTaskReconciler_createJobFunctionAndContainer(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+
+	fakeClient := fake.NewClientBuilder().Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+
+	taskDefinition := makeTaskDefinitionWithContainer(taskDefinitionName, namespace)
+	taskDefinition.Spec.Function.HttpReference.Url = "https://example.com/function.ts"
+	err = fakeClient.Create(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	r := &KeptnTaskReconciler{
+		Client:   fakeClient,
+		Recorder: record.NewFakeRecorder(100),
+		Log:      ctrl.Log.WithName("task-controller"),
+		Scheme:   fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	err = r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.ErrorIs(t, err, controllererrors.ErrFunctionAndContainerDefined)
+	require.Empty(t, task.Status.JobName)
+}
+
+func TestKeptnTaskReconciler_createJobNoFunctionOrContainer(t *testing.T) {
+	namespace := "default"
+	taskDefinitionName := "my-task-definition"
+
+	fakeClient := fake.NewClientBuilder().Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+
+	taskDefinition := makeTaskDefinitionWithContainer(taskDefinitionName, namespace)
+	taskDefinition.Spec.Container = nil
+	err = fakeClient.Create(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	recorder := record.NewFakeRecorder(100)
+	r := &KeptnTaskReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Log:      ctrl.Log.WithName("task-controller"),
+		Scheme:   fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	err = r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.ErrorIs(t, err, controllererrors.ErrNoFunctionOrContainerDefined)
+	require.Empty(t, task.Status.JobName)
+	require.Contains(t, <-recorder.Events, "TaskDefinitionInvalid")
+}
+
+func makeTaskDefinitionWithContainer(name, namespace string) *klcv1alpha2.KeptnTaskDefinition {
+	return &klcv1alpha2.KeptnTaskDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: klcv1alpha2.KeptnTaskDefinitionSpec{
+			Container: &klcv1alpha2.ContainerSpec{
+				Image:   "busybox:1.36",
+				Command: []string{"sh", "-c"},
+				Args:    []string{"echo $GREETING"},
+				Env:     []v1.EnvVar{{Name: "GREETING", Value: "hello"}},
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+				VolumeMounts: []v1.VolumeMount{{Name: "scratch", MountPath: "/scratch"}},
+				Volumes: []v1.Volume{{
+					Name:         "scratch",
+					VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+				}},
+				ServiceAccountName: "my-service-account",
+			},
+		},
+	}
+}
@@ -32,6 +32,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -47,6 +48,10 @@ type KeptnTaskReconciler struct {
 	Log      logr.Logger
 	Meters   apicommon.KeptnMeters
 	Tracer   trace.Tracer
+	// KubeClient reads the logs of failed task pods, the logs are left out of the failure details without it
+	KubeClient kubernetes.Interface
+	// Namespace is the namespace of the operator, only the KeptnConfig in it provides the defaults of the task pods
+	Namespace string
 }
 
 //+kubebuilder:rbac:groups=lifecycle.keptn.sh,resources=keptntasks,verbs=get;list;watch;create;update;patch;delete
@@ -55,6 +60,9 @@ type KeptnTaskReconciler struct {
 //+kubebuilder:rbac:groups=core,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;get;update;list;watch
 //+kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get;list
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=options.keptn.sh,resources=keptnconfigs,verbs=get;list;watch
 
 func (r *KeptnTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log.Info("Reconciling KeptnTask")
@@ -0,0 +1,62 @@
+package keptntask
+
+import (
+	"context"
+	"strings"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// failureLogLines is the number of log lines of a failed container that are kept in the task status
+	failureLogLines = int64(20)
+	// failureLogBytes limits the size of the logs in the task status, in case of very long lines
+	failureLogBytes = int64(4096)
+)
+
+// getFailureDetails returns the exit code, termination reason and last log lines of the failed container of the job,
+// jobs that were stopped before their container terminated have no details
+func (r *KeptnTaskReconciler) getFailureDetails(ctx context.Context, job *batchv1.Job) *klcv1alpha2.TaskFailureDetails {
+	pods, err := r.getJobPods(ctx, job)
+	if err != nil {
+		r.Log.Error(err, "could not get pods of job "+job.Name)
+		return nil
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			return &klcv1alpha2.TaskFailureDetails{
+				ExitCode: terminated.ExitCode,
+				Reason:   terminated.Reason,
+				Logs:     r.getContainerLogs(ctx, pod, status.Name),
+			}
+		}
+	}
+	return nil
+}
+
+func (r *KeptnTaskReconciler) getContainerLogs(ctx context.Context, pod corev1.Pod, container string) string {
+	if r.KubeClient == nil {
+		return ""
+	}
+	tailLines := failureLogLines
+	limitBytes := failureLogBytes
+	logs, err := r.KubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+	}).DoRaw(ctx)
+	if err != nil {
+		r.Log.Error(err, "could not get logs of pod "+pod.Name)
+		return ""
+	}
+	return strings.TrimSpace(string(logs))
+}
@@ -0,0 +1,109 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeptnTaskReconciler_updateJobFailureDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		pod         *v1.Pod
+		withLogs    bool
+		wantDetails *klcv1alpha2.TaskFailureDetails
+	}{
+		{
+			name:     "failed container",
+			pod:      makeFailedJobPod("my.job", "default", 1, "Error"),
+			withLogs: true,
+			wantDetails: &klcv1alpha2.TaskFailureDetails{
+				ExitCode: 1,
+				Reason:   "Error",
+				// the fake clientset returns the same logs for every pod
+				Logs: "fake logs",
+			},
+		},
+		{
+			name: "without logs",
+			pod:  makeFailedJobPod("my.job", "default", 137, "OOMKilled"),
+			wantDetails: &klcv1alpha2.TaskFailureDetails{
+				ExitCode: 137,
+				Reason:   "OOMKilled",
+			},
+		},
+		{
+			name: "pod removed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := "default"
+			job := makeJob("my.job", namespace)
+			job.Status.Failed = 1
+
+			builder := fake.NewClientBuilder().WithObjects(job)
+			if tt.pod != nil {
+				builder = builder.WithObjects(tt.pod)
+			}
+			fakeClient := builder.Build()
+			err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+			require.Nil(t, err)
+
+			recorder := record.NewFakeRecorder(100)
+			r := &KeptnTaskReconciler{
+				Client:   fakeClient,
+				Recorder: recorder,
+				Log:      ctrl.Log.WithName("task-controller"),
+				Scheme:   fakeClient.Scheme(),
+			}
+			if tt.withLogs {
+				r.KubeClient = k8sfake.NewSimpleClientset(tt.pod)
+			}
+
+			task := makeTask("my-task", namespace, "my-task-definition")
+			noRetries := int32(0)
+			task.Spec.Retries = &noRetries
+			task.Status.JobName = job.Name
+
+			err = r.updateJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+			require.Nil(t, err)
+
+			require.Equal(t, apicommon.StateFailed, task.Status.Status)
+			require.Equal(t, tt.wantDetails, task.Status.FailureDetails)
+			// the failure is reported once, by the app or workload that runs the task
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func makeFailedJobPod(jobName, namespace string, exitCode int32, reason string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-pod",
+			Namespace: namespace,
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodFailed,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "keptn-function-runner",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason},
+					},
+				},
+			},
+		},
+	}
+}
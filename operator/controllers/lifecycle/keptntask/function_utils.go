@@ -1,18 +1,13 @@
 package keptntask
 
 import (
-	"encoding/json"
 	"fmt"
-	"math/rand"
 	"os"
 
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
-	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type FunctionExecutionParams struct {
@@ -24,58 +19,16 @@ type FunctionExecutionParams struct {
 }
 
 func (r *KeptnTaskReconciler) generateFunctionJob(task *klcv1alpha2.KeptnTask, params FunctionExecutionParams) (*batchv1.Job, error) {
-	randomId := rand.Intn(99999-10000) + 10000
-	jobId := fmt.Sprintf("klc-%s-%d", apicommon.TruncateString(task.Name, apicommon.MaxTaskNameLength), randomId)
-	job := &batchv1.Job{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobId,
-			Namespace: task.Namespace,
-			Labels:    task.CreateKeptnLabels(),
-		},
-		Spec: batchv1.JobSpec{
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					RestartPolicy: "OnFailure",
-				},
-			},
-		},
-	}
-	err := controllerutil.SetControllerReference(task, job, r.Scheme)
-	if err != nil {
-		r.Log.Error(err, "could not set controller reference:")
-	}
+	job := r.newTaskJob(task)
 
 	container := corev1.Container{
 		Name:  "keptn-function-runner",
 		Image: os.Getenv("FUNCTION_RUNNER_IMAGE"),
 	}
 
-	var envVars []corev1.EnvVar
-
-	if len(params.Parameters) > 0 {
-		jsonParams, err := json.Marshal(params.Parameters)
-		if err != nil {
-			return job, controllererrors.ErrCannotMarshalParams
-		}
-		envVars = append(envVars, corev1.EnvVar{Name: "DATA", Value: string(jsonParams)})
-	}
-
-	jsonParams, err := json.Marshal(params.Context)
+	envVars, err := getTaskEnvVars(params.Parameters, params.Context, params.SecureParameters)
 	if err != nil {
-		return job, controllererrors.ErrCannotMarshalParams
-	}
-	envVars = append(envVars, corev1.EnvVar{Name: "CONTEXT", Value: string(jsonParams)})
-
-	if params.SecureParameters != "" {
-		envVars = append(envVars, corev1.EnvVar{
-			Name: "SECURE_DATA",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: params.SecureParameters},
-					Key:                  "SECURE_DATA",
-				},
-			},
-		})
+		return job, err
 	}
 
 	// Mount the function code if a ConfigMap is provided
@@ -2,16 +2,23 @@ package keptntask
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"reflect"
+	"math/rand"
+	"time"
 
 	"github.com/imdario/mergo"
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
 	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	controllercommon "github.com/keptn/lifecycle-toolkit/operator/controllers/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, task *klcv1alpha2.KeptnTask) error {
@@ -22,13 +29,30 @@ func (r *KeptnTaskReconciler) createJob(ctx context.Context, req ctrl.Request, t
 		return err
 	}
 
-	if !reflect.DeepEqual(definition.Spec.Function, klcv1alpha2.FunctionSpec{}) {
+	// the validating webhook rejects such definitions, but it may not have been running when they were created
+	hasFunction := definition.Spec.HasFunction()
+	if hasFunction && definition.Spec.Container != nil {
+		controllercommon.RecordEvent(r.Recorder, apicommon.PhaseCreateTask, "Warning", task, "TaskDefinitionInvalid", fmt.Sprintf("KeptnTaskDefinition %s has both a function and a container", task.Spec.TaskDefinition), "")
+		return controllererrors.ErrFunctionAndContainerDefined
+	}
+	if !hasFunction && definition.Spec.Container == nil {
+		controllercommon.RecordEvent(r.Recorder, apicommon.PhaseCreateTask, "Warning", task, "TaskDefinitionInvalid", fmt.Sprintf("KeptnTaskDefinition %s has neither a function nor a container", task.Spec.TaskDefinition), "")
+		return controllererrors.ErrNoFunctionOrContainerDefined
+	}
+
+	if definition.Spec.Container != nil {
+		jobName, err = r.createContainerJob(ctx, task, definition)
+		if err != nil {
+			return err
+		}
+	} else {
 		jobName, err = r.createFunctionJob(ctx, req, task, definition)
 		if err != nil {
 			return err
 		}
 	}
 
+	task.Status.Attempt = getAttempt(task) + 1
 	task.Status.JobName = jobName
 	task.Status.Status = apicommon.StatePending
 	err = r.Client.Status().Update(ctx, task)
@@ -50,7 +74,10 @@ func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Re
 		}
 	}
 
-	params.Context = setupTaskContext(task)
+	params.Context, err = r.getTaskContext(ctx, task)
+	if err != nil {
+		return "", err
+	}
 
 	if len(task.Spec.Parameters.Inline) > 0 {
 		err = mergo.Merge(&params.Parameters, task.Spec.Parameters.Inline)
@@ -68,6 +95,33 @@ func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Re
 	if err != nil {
 		return "", err
 	}
+	return r.createTaskJob(ctx, task, definition, job)
+}
+
+func (r *KeptnTaskReconciler) createContainerJob(ctx context.Context, task *klcv1alpha2.KeptnTask, definition *klcv1alpha2.KeptnTaskDefinition) (string, error) {
+	taskContext, err := r.getTaskContext(ctx, task)
+	if err != nil {
+		return "", err
+	}
+	job, err := r.generateContainerJob(task, *definition.Spec.Container, taskContext)
+	if err != nil {
+		return "", err
+	}
+	return r.createTaskJob(ctx, task, definition, job)
+}
+
+// createTaskJob creates the job of the next attempt of the task
+func (r *KeptnTaskReconciler) createTaskJob(ctx context.Context, task *klcv1alpha2.KeptnTask, definition *klcv1alpha2.KeptnTaskDefinition, job *batchv1.Job) (string, error) {
+	if timeout := task.GetTimeout(definition.Spec); timeout > 0 {
+		setJobTimeout(job, timeout)
+	}
+	template, err := r.getPodTemplate(ctx, definition)
+	if err != nil {
+		r.Log.Error(err, "could not get pod template")
+		return "", err
+	}
+	applyPodTemplate(job, template)
+
 	err = r.Client.Create(ctx, job)
 	if err != nil {
 		r.Log.Error(err, "could not create job")
@@ -79,6 +133,66 @@ func (r *KeptnTaskReconciler) createFunctionJob(ctx context.Context, req ctrl.Re
 	return job.Name, nil
 }
 
+// newTaskJob returns a job without containers for an attempt of the task
+func (r *KeptnTaskReconciler) newTaskJob(task *klcv1alpha2.KeptnTask) *batchv1.Job {
+	randomId := rand.Intn(99999-10000) + 10000
+	jobId := fmt.Sprintf("klc-%s-%d", apicommon.TruncateString(task.Name, apicommon.MaxTaskNameLength), randomId)
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobId,
+			Namespace: task.Namespace,
+			Labels:    task.CreateKeptnLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			// every attempt of the task runs in its own job, so that the task controller can retry it after an interval
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+	err := controllerutil.SetControllerReference(task, job, r.Scheme)
+	if err != nil {
+		r.Log.Error(err, "could not set controller reference:")
+	}
+	return job
+}
+
+// getTaskEnvVars returns the environment variables that pass the parameters and the context to the task
+func getTaskEnvVars(parameters map[string]string, taskContext klcv1alpha2.TaskContext, secureParameters string) ([]corev1.EnvVar, error) {
+	var envVars []corev1.EnvVar
+
+	if len(parameters) > 0 {
+		jsonParams, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, controllererrors.ErrCannotMarshalParams
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: "DATA", Value: string(jsonParams)})
+	}
+
+	jsonParams, err := json.Marshal(taskContext)
+	if err != nil {
+		return nil, controllererrors.ErrCannotMarshalParams
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: "CONTEXT", Value: string(jsonParams)})
+
+	if secureParameters != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "SECURE_DATA",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secureParameters},
+					Key:                  "SECURE_DATA",
+				},
+			},
+		})
+	}
+	return envVars, nil
+}
+
 func (r *KeptnTaskReconciler) updateJob(ctx context.Context, req ctrl.Request, task *klcv1alpha2.KeptnTask) error {
 	job, err := r.getJob(ctx, task.Status.JobName, req.Namespace)
 	if err != nil {
@@ -92,11 +206,103 @@ func (r *KeptnTaskReconciler) updateJob(ctx context.Context, req ctrl.Request, t
 	}
 	if job.Status.Succeeded > 0 {
 		task.Status.Status = apicommon.StateSucceeded
-	} else if job.Status.Failed > 0 {
+		// the failure of a previous attempt does not describe the task anymore
+		task.Status.Message = ""
+		task.Status.FailureDetails = nil
+		r.setTaskOutput(ctx, task, job)
+	} else if failedAt, failed := getJobFailure(job); failed {
+		return r.handleFailedJob(ctx, req, task, job, failedAt)
+	}
+	return nil
+}
+
+// handleFailedJob starts the next attempt of the task once the retry interval has passed,
+// or fails the task if it has no retries left
+func (r *KeptnTaskReconciler) handleFailedJob(ctx context.Context, req ctrl.Request, task *klcv1alpha2.KeptnTask, job *batchv1.Job, failedAt time.Time) error {
+	// without definition, the settings of the task and the defaults are used
+	definition, err := r.getTaskDefinition(ctx, task.Spec.TaskDefinition, req.Namespace)
+	if err != nil {
+		r.Log.Error(err, "could not get KeptnTaskDefinition of failed task "+task.Name)
+	}
+	attempt := getAttempt(task)
+	message := getJobFailureMessage(job, task.GetTimeout(definition.Spec))
+
+	if attempt > task.GetRetries(definition.Spec) {
 		task.Status.Status = apicommon.StateFailed
+		task.Status.Message = fmt.Sprintf("attempt %d failed: %s", attempt, message)
+		task.Status.FailureDetails = r.getFailureDetails(ctx, job)
+		// the failure is reported by the app or workload that runs the task, see TaskHandler.setTaskFailureEvents
+		return nil
+	}
+	if time.Since(failedAt) < task.GetRetryInterval(definition.Spec) {
+		return nil
 	}
+
+	task.Status.Message = fmt.Sprintf("attempt %d failed: %s", attempt, message)
+	task.Status.FailureDetails = r.getFailureDetails(ctx, job)
+	controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileTask, "Warning", task, "Retry", task.GetFailureMessage(), "")
+	if err := r.createJob(ctx, req, task); err != nil {
+		return err
+	}
+	task.Status.Status = apicommon.StateProgressing
 	return nil
 }
+
+// getAttempt returns the current attempt of the task, tasks created before attempts were counted are in their first one
+func getAttempt(task *klcv1alpha2.KeptnTask) int32 {
+	if task.Status.Attempt < 1 && task.Status.JobName != "" {
+		return 1
+	}
+	return task.Status.Attempt
+}
+
+// setJobTimeout sets the active deadline of the job, rounded up to whole seconds
+// because the API server rejects a deadline of 0
+func setJobTimeout(job *batchv1.Job, timeout time.Duration) {
+	seconds := int64((timeout + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	job.Spec.ActiveDeadlineSeconds = &seconds
+}
+
+// getJobFailure checks whether the job failed and returns when it failed
+func getJobFailure(job *batchv1.Job) (time.Time, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	if job.Status.Failed > 0 {
+		return time.Time{}, true
+	}
+	return time.Time{}, false
+}
+
+func getJobFailureMessage(job *batchv1.Job, timeout time.Duration) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type != batchv1.JobFailed || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Reason == "DeadlineExceeded" {
+			return fmt.Sprintf("task timed out after %s", timeout)
+		}
+		if condition.Message != "" {
+			return fmt.Sprintf("job %s failed: %s", job.Name, condition.Message)
+		}
+	}
+	return fmt.Sprintf("job %s failed", job.Name)
+}
+
+// getJobPods returns the pods that the job created
+func (r *KeptnTaskReconciler) getJobPods(ctx context.Context, job *batchv1.Job) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
 func (r *KeptnTaskReconciler) getJob(ctx context.Context, jobName string, namespace string) (*batchv1.Job, error) {
 	job := &batchv1.Job{}
 	err := r.Client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: namespace}, job)
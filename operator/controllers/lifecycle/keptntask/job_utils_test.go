@@ -3,9 +3,11 @@ package keptntask
 import (
 	"context"
 	"testing"
+	"time"
 
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
 	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/options/v1alpha1"
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -29,6 +31,8 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 
 	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
 	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
 
 	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
 
@@ -78,6 +82,11 @@ func TestKeptnTaskReconciler_createJob(t *testing.T) {
 	require.NotEmpty(t, resultingJob.OwnerReferences)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers, 1)
 	require.Len(t, resultingJob.Spec.Template.Spec.Containers[0].Env, 4)
+	require.Equal(t, int32(1), task.Status.Attempt)
+	// attempts without timeout are not stopped
+	require.Nil(t, resultingJob.Spec.ActiveDeadlineSeconds)
+	require.Equal(t, int32(0), *resultingJob.Spec.BackoffLimit)
+	require.Equal(t, v1.RestartPolicyNever, resultingJob.Spec.Template.Spec.RestartPolicy)
 }
 
 func TestKeptnTaskReconciler_updateJob(t *testing.T) {
@@ -92,6 +101,8 @@ func TestKeptnTaskReconciler_updateJob(t *testing.T) {
 
 	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
 	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
 
 	job.Status.Failed = 1
 
@@ -106,6 +117,8 @@ func TestKeptnTaskReconciler_updateJob(t *testing.T) {
 	}
 
 	task := makeTask("my-task", namespace, taskDefinitionName)
+	noRetries := int32(0)
+	task.Spec.Retries = &noRetries
 
 	err = fakeClient.Create(context.TODO(), task)
 	require.Nil(t, err)
@@ -122,10 +135,12 @@ func TestKeptnTaskReconciler_updateJob(t *testing.T) {
 	require.Nil(t, err)
 
 	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, "attempt 1 failed: job my.job failed", task.Status.Message)
 
 	// now, set the job to succeeded
 	job.Status.Succeeded = 1
 	job.Status.Failed = 0
+	task.Status.FailureDetails = &klcv1alpha2.TaskFailureDetails{ExitCode: 1}
 
 	err = fakeClient.Status().Update(context.TODO(), job)
 	require.Nil(t, err)
@@ -134,6 +149,93 @@ func TestKeptnTaskReconciler_updateJob(t *testing.T) {
 	require.Nil(t, err)
 
 	require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+	// the failure of the previous attempt is cleared
+	require.Empty(t, task.Status.Message)
+	require.Nil(t, task.Status.FailureDetails)
+}
+
+func TestKeptnTaskReconciler_updateJobRetry(t *testing.T) {
+	namespace := "default"
+	cmName := "my-cmd"
+	taskDefinitionName := "my-task-definition"
+	retries := int32(1)
+
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
+	taskDefinition.Spec.Retries = &retries
+	taskDefinition.Spec.Timeout = metav1.Duration{Duration: time.Minute}
+	taskDefinition.Spec.RetryInterval = metav1.Duration{Duration: time.Minute}
+	taskDefinition.Status.Function.ConfigMap = cmName
+
+	job := makeJob("my.job", namespace)
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:               batchv1.JobFailed,
+			Status:             v1.ConditionTrue,
+			Reason:             "DeadlineExceeded",
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(makeConfigMap(cmName, namespace), job).Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = fakeClient.Create(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	r := &KeptnTaskReconciler{
+		Client:   fakeClient,
+		Recorder: record.NewFakeRecorder(100),
+		Log:      ctrl.Log.WithName("task-controller"),
+		Scheme:   fakeClient.Scheme(),
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+	task.Status.JobName = job.Name
+	task.Status.Attempt = 1
+	task.Status.Status = apicommon.StateProgressing
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+		},
+	}
+
+	// the retry interval has not passed yet
+	err = r.updateJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Equal(t, job.Name, task.Status.JobName)
+
+	// the next attempt runs in a new job
+	job.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	err = fakeClient.Status().Update(context.TODO(), job)
+	require.Nil(t, err)
+
+	err = r.updateJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateProgressing, task.Status.Status)
+	require.Equal(t, int32(2), task.Status.Attempt)
+	require.Equal(t, "attempt 1 failed: task timed out after 1m0s", task.Status.Message)
+	require.NotEqual(t, job.Name, task.Status.JobName)
+
+	// the retries are used up
+	secondJob := &batchv1.Job{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.JobName}, secondJob)
+	require.Nil(t, err)
+	require.Equal(t, int64(60), *secondJob.Spec.ActiveDeadlineSeconds)
+	secondJob.Status.Failed = 1
+	err = fakeClient.Status().Update(context.TODO(), secondJob)
+	require.Nil(t, err)
+
+	err = r.updateJob(context.TODO(), req, task)
+	require.Nil(t, err)
+	require.Equal(t, apicommon.StateFailed, task.Status.Status)
+	require.Equal(t, int32(2), task.Status.Attempt)
 }
 
 func makeJob(name, namespace string) *batchv1.Job {
@@ -190,3 +292,34 @@ func makeConfigMap(name, namespace string) *v1.ConfigMap {
 		},
 	}
 }
+
+func Test_setJobTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    int64
+	}{
+		{
+			name:    "whole seconds",
+			timeout: 5 * time.Minute,
+			want:    300,
+		},
+		{
+			name:    "sub-second timeout is rounded up to one second",
+			timeout: 500 * time.Millisecond,
+			want:    1,
+		},
+		{
+			name:    "fractions of a second are rounded up",
+			timeout: 1500 * time.Millisecond,
+			want:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{}
+			setJobTimeout(job, tt.timeout)
+			require.Equal(t, tt.want, *job.Spec.ActiveDeadlineSeconds)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package keptntask
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	controllercommon "github.com/keptn/lifecycle-toolkit/operator/controllers/common"
+	controllererrors "github.com/keptn/lifecycle-toolkit/operator/controllers/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// setTaskOutput stores the output of the succeeded job in the status of the task,
+// an invalid output does not fail the task
+func (r *KeptnTaskReconciler) setTaskOutput(ctx context.Context, task *klcv1alpha2.KeptnTask, job *batchv1.Job) {
+	output, err := r.getJobOutput(ctx, job)
+	if err != nil {
+		r.Log.Error(err, "could not get output of job "+job.Name)
+		controllercommon.RecordEvent(r.Recorder, apicommon.PhaseReconcileTask, "Warning", task, "OutputInvalid", fmt.Sprintf("could not get output of Job %s: %s", job.Name, err), "")
+		return
+	}
+	task.Status.Output = output
+}
+
+// getJobOutput returns the output that the task wrote to the termination message of its container
+func (r *KeptnTaskReconciler) getJobOutput(ctx context.Context, job *batchv1.Job) (map[string]string, error) {
+	pods, err := r.getJobPods(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated == nil || status.State.Terminated.Message == "" {
+				continue
+			}
+			output := map[string]string{}
+			if err := json.Unmarshal([]byte(status.State.Terminated.Message), &output); err != nil {
+				return nil, fmt.Errorf("%w: %s", controllererrors.ErrInvalidTaskOutput, err)
+			}
+			return output, nil
+		}
+	}
+	return nil, nil
+}
+
+// getTaskContext returns the context of the task, including the outputs of the tasks that succeeded before it
+func (r *KeptnTaskReconciler) getTaskContext(ctx context.Context, task *klcv1alpha2.KeptnTask) (klcv1alpha2.TaskContext, error) {
+	taskContext := setupTaskContext(task)
+	outputs, err := controllercommon.GetTaskOutputs(ctx, r.Client, task)
+	if err != nil {
+		return taskContext, err
+	}
+	taskContext.Outputs = outputs
+	return taskContext, nil
+}
@@ -0,0 +1,158 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeptnTaskReconciler_updateJobOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantOutput map[string]string
+		wantEvent  bool
+	}{
+		{
+			name:       "output",
+			message:    `{"ticketId":"CHG-42"}`,
+			wantOutput: map[string]string{"ticketId": "CHG-42"},
+		},
+		{
+			name: "no output",
+		},
+		{
+			name:      "invalid output",
+			message:   `{"ticketId":42}`,
+			wantEvent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := "default"
+			job := makeJob("my.job", namespace)
+			job.Status.Succeeded = 1
+			pod := makeJobPod(job.Name, namespace, tt.message)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(job, pod).Build()
+			err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+			require.Nil(t, err)
+
+			recorder := record.NewFakeRecorder(100)
+			r := &KeptnTaskReconciler{
+				Client:   fakeClient,
+				Recorder: recorder,
+				Log:      ctrl.Log.WithName("task-controller"),
+				Scheme:   fakeClient.Scheme(),
+			}
+
+			task := makeTask("my-task", namespace, "my-task-definition")
+			task.Status.JobName = job.Name
+
+			err = r.updateJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+			require.Nil(t, err)
+
+			require.Equal(t, apicommon.StateSucceeded, task.Status.Status)
+			require.Equal(t, tt.wantOutput, task.Status.Output)
+			if tt.wantEvent {
+				require.Contains(t, <-recorder.Events, "OutputInvalid")
+			} else {
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestKeptnTaskReconciler_getTaskContext(t *testing.T) {
+	namespace := "default"
+	owner := metav1.OwnerReference{
+		APIVersion: "lifecycle.keptn.sh/v1alpha2",
+		Kind:       "KeptnAppVersion",
+		Name:       "my-app-0.1.0",
+		UID:        "app-version",
+		Controller: boolPtr(true),
+	}
+	otherOwner := owner
+	otherOwner.UID = "other-app-version"
+
+	task := makeTask("my-task", namespace, "notify")
+	task.OwnerReferences = []metav1.OwnerReference{owner}
+
+	ticket := makeTask("ticket", namespace, "create-ticket")
+	ticket.OwnerReferences = []metav1.OwnerReference{owner}
+	ticket.Status.Status = apicommon.StateSucceeded
+	ticket.Status.Output = map[string]string{"ticketId": "CHG-42"}
+
+	running := makeTask("running", namespace, "running")
+	running.OwnerReferences = []metav1.OwnerReference{owner}
+	running.Status.Status = apicommon.StateProgressing
+	running.Status.Output = map[string]string{"foo": "bar"}
+
+	other := makeTask("other", namespace, "create-ticket")
+	other.OwnerReferences = []metav1.OwnerReference{otherOwner}
+	other.Status.Status = apicommon.StateSucceeded
+	other.Status.Output = map[string]string{"ticketId": "CHG-1"}
+
+	fakeClient := fake.NewClientBuilder().Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	for _, obj := range []*klcv1alpha2.KeptnTask{task, ticket, running, other} {
+		status := obj.Status
+		err = fakeClient.Create(context.TODO(), obj)
+		require.Nil(t, err)
+		obj.Status = status
+		err = fakeClient.Status().Update(context.TODO(), obj)
+		require.Nil(t, err)
+	}
+
+	r := &KeptnTaskReconciler{
+		Client: fakeClient,
+		Log:    ctrl.Log.WithName("task-controller"),
+		Scheme: fakeClient.Scheme(),
+	}
+
+	taskContext, err := r.getTaskContext(context.TODO(), task)
+	require.Nil(t, err)
+	require.Equal(t, "my-app", taskContext.AppName)
+	require.Equal(t, map[string]map[string]string{"create-ticket": {"ticketId": "CHG-42"}}, taskContext.Outputs)
+
+	// tasks without owner do not get outputs
+	task.OwnerReferences = nil
+	taskContext, err = r.getTaskContext(context.TODO(), task)
+	require.Nil(t, err)
+	require.Nil(t, taskContext.Outputs)
+}
+
+func makeJobPod(jobName, namespace, message string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-pod",
+			Namespace: namespace,
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "keptn-function-runner",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Message: message},
+					},
+				},
+			},
+		},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,87 @@
+package keptntask
+
+import (
+	"context"
+	"sort"
+
+	"github.com/imdario/mergo"
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/options/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getPodTemplate returns the pod template of the definition, completed with the defaults of the KeptnConfig
+// in the namespace of the operator
+func (r *KeptnTaskReconciler) getPodTemplate(ctx context.Context, definition *klcv1alpha2.KeptnTaskDefinition) (*klcv1alpha2.TaskPodTemplate, error) {
+	template := &klcv1alpha2.TaskPodTemplate{}
+	if definition.Spec.PodTemplate != nil {
+		template = definition.Spec.PodTemplate.DeepCopy()
+	}
+
+	if r.Namespace == "" {
+		return template, nil
+	}
+	configs := &optionsv1alpha1.KeptnConfigList{}
+	if err := r.Client.List(ctx, configs, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+	defaults := getTaskPodDefaults(configs.Items)
+	if defaults == nil {
+		return template, nil
+	}
+	if err := mergo.Merge(template, defaults.DeepCopy()); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// getTaskPodDefaults returns the task pod template of the KeptnConfig, if there are several configs,
+// the first one by name is used
+func getTaskPodDefaults(configs []optionsv1alpha1.KeptnConfig) *klcv1alpha2.TaskPodTemplate {
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+	for _, config := range configs {
+		if config.Spec.TaskPodTemplate != nil {
+			return config.Spec.TaskPodTemplate
+		}
+	}
+	return nil
+}
+
+// applyPodTemplate sets the fields of the template that are not already set by the task on the pod of the job
+func applyPodTemplate(job *batchv1.Job, template *klcv1alpha2.TaskPodTemplate) {
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.ServiceAccountName == "" {
+		podSpec.ServiceAccountName = template.ServiceAccountName
+	}
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = template.NodeSelector
+	}
+	if podSpec.Tolerations == nil {
+		podSpec.Tolerations = template.Tolerations
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = template.Affinity
+	}
+	if podSpec.ImagePullSecrets == nil {
+		podSpec.ImagePullSecrets = template.ImagePullSecrets
+	}
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = template.SecurityContext
+	}
+
+	if template.Container == nil {
+		return
+	}
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if template.Container.Resources != nil && container.Resources.Limits == nil && container.Resources.Requests == nil {
+			container.Resources = *template.Container.Resources
+		}
+		if container.SecurityContext == nil {
+			container.SecurityContext = template.Container.SecurityContext
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package keptntask
+
+import (
+	"context"
+	"testing"
+
+	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	optionsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/options/v1alpha1"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeptnTaskReconciler_createJobWithPodTemplate(t *testing.T) {
+	namespace := "default"
+	cmName := "my-cmd"
+	taskDefinitionName := "my-task-definition"
+	runAsNonRoot := true
+	allowPrivilegeEscalation := false
+
+	config := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "keptn-config", Namespace: "keptn-lifecycle-toolkit-system"},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			TaskPodTemplate: &klcv1alpha2.TaskPodTemplate{
+				ServiceAccountName: "default-task-runner",
+				NodeSelector:       map[string]string{"pool": "default"},
+				SecurityContext:    &v1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+				Container: &klcv1alpha2.TaskContainerTemplate{
+					SecurityContext: &v1.SecurityContext{AllowPrivilegeEscalation: &allowPrivilegeEscalation},
+				},
+			},
+		},
+	}
+
+	// KeptnConfigs outside the namespace of the operator are ignored
+	otherConfig := &optionsv1alpha1.KeptnConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-config", Namespace: namespace},
+		Spec: optionsv1alpha1.KeptnConfigSpec{
+			TaskPodTemplate: &klcv1alpha2.TaskPodTemplate{
+				ServiceAccountName: "other-task-runner",
+			},
+		},
+	}
+
+	resources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+	taskDefinition := makeTaskDefinitionWithConfigmapRef(taskDefinitionName, namespace, cmName)
+	taskDefinition.Spec.PodTemplate = &klcv1alpha2.TaskPodTemplate{
+		NodeSelector:     map[string]string{"pool": "tasks"},
+		Tolerations:      []v1.Toleration{{Key: "tasks", Operator: v1.TolerationOpExists}},
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+		Container: &klcv1alpha2.TaskContainerTemplate{
+			Resources: &resources,
+		},
+	}
+	taskDefinition.Status.Function.ConfigMap = cmName
+
+	fakeClient := fake.NewClientBuilder().WithObjects(makeConfigMap(cmName, namespace)).Build()
+	err := klcv1alpha2.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = optionsv1alpha1.AddToScheme(fakeClient.Scheme())
+	require.Nil(t, err)
+	err = fakeClient.Create(context.TODO(), config)
+	require.Nil(t, err)
+	err = fakeClient.Create(context.TODO(), otherConfig)
+	require.Nil(t, err)
+	err = fakeClient.Create(context.TODO(), taskDefinition)
+	require.Nil(t, err)
+
+	r := &KeptnTaskReconciler{
+		Client:    fakeClient,
+		Recorder:  record.NewFakeRecorder(100),
+		Log:       ctrl.Log.WithName("task-controller"),
+		Scheme:    fakeClient.Scheme(),
+		Namespace: "keptn-lifecycle-toolkit-system",
+	}
+
+	task := makeTask("my-task", namespace, taskDefinitionName)
+	err = fakeClient.Create(context.TODO(), task)
+	require.Nil(t, err)
+
+	err = r.createJob(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace}}, task)
+	require.Nil(t, err)
+
+	job := &batchv1.Job{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: task.Status.JobName}, job)
+	require.Nil(t, err)
+
+	podSpec := job.Spec.Template.Spec
+	// set by the definition
+	require.Equal(t, map[string]string{"pool": "tasks"}, podSpec.NodeSelector)
+	require.Equal(t, taskDefinition.Spec.PodTemplate.Tolerations, podSpec.Tolerations)
+	require.Equal(t, taskDefinition.Spec.PodTemplate.ImagePullSecrets, podSpec.ImagePullSecrets)
+	require.Equal(t, resources, podSpec.Containers[0].Resources)
+	// defaults of the KeptnConfig
+	require.Equal(t, "default-task-runner", podSpec.ServiceAccountName)
+	require.Equal(t, &runAsNonRoot, podSpec.SecurityContext.RunAsNonRoot)
+	require.Equal(t, &allowPrivilegeEscalation, podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+}
+
+func TestApplyPodTemplate(t *testing.T) {
+	resources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+	template := &klcv1alpha2.TaskPodTemplate{
+		ServiceAccountName: "template",
+		Container: &klcv1alpha2.TaskContainerTemplate{
+			Resources: &v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+	}
+
+	// the settings of a container task take precedence over the template
+	job := &batchv1.Job{}
+	job.Spec.Template.Spec.ServiceAccountName = "task"
+	job.Spec.Template.Spec.Containers = []v1.Container{{Name: "task", Resources: resources}}
+	applyPodTemplate(job, template)
+	require.Equal(t, "task", job.Spec.Template.Spec.ServiceAccountName)
+	require.Equal(t, resources, job.Spec.Template.Spec.Containers[0].Resources)
+
+	job = &batchv1.Job{}
+	job.Spec.Template.Spec.Containers = []v1.Container{{Name: "task"}}
+	applyPodTemplate(job, template)
+	require.Equal(t, "template", job.Spec.Template.Spec.ServiceAccountName)
+	require.Equal(t, *template.Container.Resources, job.Spec.Template.Spec.Containers[0].Resources)
+}
+
+func TestGetTaskPodDefaults(t *testing.T) {
+	require.Nil(t, getTaskPodDefaults(nil))
+
+	configs := []optionsv1alpha1.KeptnConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "keptn"},
+			Spec:       optionsv1alpha1.KeptnConfigSpec{TaskPodTemplate: &klcv1alpha2.TaskPodTemplate{ServiceAccountName: "b"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "keptn"},
+			Spec:       optionsv1alpha1.KeptnConfigSpec{TaskPodTemplate: &klcv1alpha2.TaskPodTemplate{ServiceAccountName: "a"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "keptn"},
+		},
+	}
+	require.Equal(t, "a", getTaskPodDefaults(configs).ServiceAccountName)
+}
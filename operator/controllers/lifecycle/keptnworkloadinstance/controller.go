@@ -109,6 +109,18 @@ func (r *KeptnWorkloadInstanceReconciler) Reconcile(ctx context.Context, req ctr
 		r.Log.Error(err, "could not get span")
 	}
 
+	// once a phase has failed, only the on-failure tasks are left to run
+	if workloadInstance.Status.Status.IsFailed() && len(workloadInstance.Spec.OnFailureTasks) > 0 {
+		if workloadInstance.IsOnFailureCompleted() {
+			return ctrl.Result{}, nil
+		}
+		reconcileOnFailure := func(phaseCtx context.Context) (apicommon.KeptnState, error) {
+			return r.reconcilePrePostDeployment(ctx, phaseCtx, workloadInstance, apicommon.OnFailureCheckType)
+		}
+		result, err := phaseHandler.HandleOnFailurePhase(ctx, ctxWorkloadTrace, r.Tracer, workloadInstance, apicommon.PhaseWorkloadOnFailure, span, reconcileOnFailure)
+		return result.Result, err
+	}
+
 	if workloadInstance.Status.CurrentPhase == "" {
 		spanWorkloadTrace.AddEvent("WorkloadInstance Pre-Deployment Tasks started", trace.WithTimestamp(time.Now()))
 		controllercommon.RecordEvent(r.Recorder, phase, "Normal", workloadInstance, "Started", "have started", workloadInstance.GetVersion())
@@ -39,6 +39,9 @@ func (r *KeptnWorkloadInstanceReconciler) reconcilePrePostDeployment(ctx context
 	case apicommon.PostDeploymentCheckType:
 		workloadInstance.Status.PostDeploymentStatus = overallState
 		workloadInstance.Status.PostDeploymentTaskStatus = newStatus
+	case apicommon.OnFailureCheckType:
+		workloadInstance.Status.OnFailureStatus = overallState
+		workloadInstance.Status.OnFailureTaskStatus = newStatus
 	}
 
 	// Write Status Field
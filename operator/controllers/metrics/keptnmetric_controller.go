@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	klcv1alpha2 "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2"
+	apicommon "github.com/keptn/lifecycle-toolkit/operator/apis/lifecycle/v1alpha2/common"
 	metricsv1alpha1 "github.com/keptn/lifecycle-toolkit/operator/apis/metrics/v1alpha1"
 	"github.com/keptn/lifecycle-toolkit/operator/controllers/common/providers"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -92,15 +93,25 @@ func (r *KeptnMetricReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 	// load the provider
-	provider, err2 := providers.NewProvider(metric.Spec.Provider.Name, r.Log, r.Client)
+	provider, err2 := providers.NewProvider(evaluationProvider.GetType(), r.Log, r.Client)
 	if err2 != nil {
 		r.Log.Error(err2, "Failed to get the correct Metric Provider")
 		return ctrl.Result{Requeue: false}, err2
 	}
 
+	query, err := apicommon.ResolveQuery(metric.Spec.Query, apicommon.MetricQueryContext{Namespace: metric.Namespace})
+	if err != nil {
+		r.Log.Error(err, "Failed to resolve the query")
+		return ctrl.Result{Requeue: false}, err
+	}
 	objective := klcv1alpha2.Objective{
 		Name:  metric.Name,
-		Query: metric.Spec.Query,
+		Query: query,
+	}
+	// the rate limit of the provider is shared with the evaluations
+	if err := providers.WaitForRateLimit(ctx, *evaluationProvider); err != nil {
+		r.Log.Error(err, "Failed to wait for the rate limit of the provider")
+		return ctrl.Result{}, err
 	}
 	value, rawValue, err := provider.EvaluateQuery(ctx, objective, *evaluationProvider)
 	if err != nil {
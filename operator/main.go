@@ -64,6 +64,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -265,13 +266,21 @@ func main() {
 
 	spanHandler := &controllercommon.SpanHandler{}
 
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes client")
+		os.Exit(1)
+	}
+
 	taskReconciler := &keptntask.KeptnTaskReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Log:      ctrl.Log.WithName("KeptnTask Controller"),
-		Recorder: mgr.GetEventRecorderFor("keptntask-controller"),
-		Meters:   meters,
-		Tracer:   otel.Tracer("keptn/operator/task"),
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Log:        ctrl.Log.WithName("KeptnTask Controller"),
+		Recorder:   mgr.GetEventRecorderFor("keptntask-controller"),
+		Meters:     meters,
+		Tracer:     otel.Tracer("keptn/operator/task"),
+		KubeClient: kubeClient,
+		Namespace:  env.PodNamespace,
 	}
 	if err = (taskReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KeptnTask")
@@ -381,6 +390,14 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnEvaluationProvider")
 		os.Exit(1)
 	}
+	if err = (&lifecyclev1alpha2.KeptnEvaluationDefinition{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnEvaluationDefinition")
+		os.Exit(1)
+	}
+	if err = (&lifecyclev1alpha2.KeptnTaskDefinition{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnTaskDefinition")
+		os.Exit(1)
+	}
 	if err = (&lifecyclev1alpha2.KeptnAppVersion{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KeptnAppVersion")
 		os.Exit(1)
@@ -3,6 +3,7 @@ module github.com/keptn/lifecycle-toolkit/scheduler
 go 1.19
 
 require (
+	github.com/hashicorp/go-version v1.6.0
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/onsi/ginkgo/v2 v2.7.0
 	github.com/onsi/gomega v1.26.0
@@ -0,0 +1,137 @@
+package klcpermit
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+)
+
+// TimeoutPolicy decides what happens to a pod whose pre-deployment checks have not finished within the permit timeout
+type TimeoutPolicy string
+
+const (
+	// TimeoutPolicyReject rejects the pod, the scheduler retries to schedule it later
+	TimeoutPolicyReject TimeoutPolicy = "reject"
+	// TimeoutPolicyAllow schedules the pod without waiting any longer
+	TimeoutPolicyAllow TimeoutPolicy = "allow"
+	// TimeoutPolicyWait keeps the pod waiting, the scheduler framework lets a pod wait for at most 15 minutes
+	// at a time, after which it is requeued and waits again in its next scheduling cycle
+	TimeoutPolicyWait TimeoutPolicy = "wait"
+)
+
+// PermitCheck is a status of the workload instance or the app version of a pod that has to succeed before the pod is scheduled
+type PermitCheck string
+
+const (
+	// CheckAppPreDeploymentTasks checks the pre-deployment tasks of the app version the pod belongs to
+	CheckAppPreDeploymentTasks PermitCheck = "appPreDeploymentTasks"
+	// CheckAppPreDeploymentEvaluations checks the pre-deployment evaluations of the app version the pod belongs to
+	CheckAppPreDeploymentEvaluations PermitCheck = "appPreDeploymentEvaluations"
+	// CheckWorkloadPreDeploymentTasks checks the pre-deployment tasks of the workload instance of the pod
+	CheckWorkloadPreDeploymentTasks PermitCheck = "workloadPreDeploymentTasks"
+	// CheckWorkloadPreDeploymentEvaluations checks the pre-deployment evaluations of the workload instance of the pod
+	CheckWorkloadPreDeploymentEvaluations PermitCheck = "workloadPreDeploymentEvaluations"
+)
+
+// defaultPermitChecks are the checks of a pod unless configured otherwise, the workload pre-deployment evaluations
+// only succeed after all other pre-deployment checks have succeeded
+var defaultPermitChecks = []PermitCheck{CheckWorkloadPreDeploymentEvaluations}
+
+const PermitTimeoutAnnotation = "keptn.sh/permit-timeout"
+const PermitTimeoutPolicyAnnotation = "keptn.sh/permit-timeout-policy"
+
+// defaultPermitTimeout is the time a pod waits for its pre-deployment checks, unless configured otherwise
+const defaultPermitTimeout = 5 * time.Minute
+
+// maxPermitWaitDuration is the longest time the scheduler framework lets a pod wait in the permit phase
+const maxPermitWaitDuration = 15 * time.Minute
+
+// Args are the arguments of the plugin in the pluginConfig of the scheduler profile
+type Args struct {
+	// Timeout is the time a pod waits for its pre-deployment checks, 5 minutes by default
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// TimeoutPolicy decides what happens to a pod when the timeout has expired, reject by default
+	TimeoutPolicy TimeoutPolicy `json:"timeoutPolicy,omitempty"`
+	// Checks are the checks that have to succeed before a pod is scheduled, in the order they are evaluated,
+	// the workload pre-deployment evaluations by default
+	Checks []PermitCheck `json:"checks,omitempty"`
+}
+
+// permitConfig is the timeout, timeout policy and checks of the permit of a pod
+type permitConfig struct {
+	timeout time.Duration
+	policy  TimeoutPolicy
+	checks  []PermitCheck
+}
+
+func parseArgs(obj runtime.Object) (permitConfig, error) {
+	args := Args{}
+	if err := frameworkruntime.DecodeInto(obj, &args); err != nil {
+		return permitConfig{}, fmt.Errorf("could not decode the arguments of %s: %w", PluginName, err)
+	}
+
+	config := permitConfig{
+		timeout: defaultPermitTimeout,
+		policy:  TimeoutPolicyReject,
+		checks:  defaultPermitChecks,
+	}
+	if args.Timeout.Duration < 0 {
+		return permitConfig{}, fmt.Errorf("the timeout of %s must not be negative: %s", PluginName, args.Timeout.Duration)
+	}
+	if args.Timeout.Duration > 0 {
+		config.timeout = args.Timeout.Duration
+	}
+	if args.TimeoutPolicy != "" {
+		if !args.TimeoutPolicy.isValid() {
+			return permitConfig{}, fmt.Errorf("unknown timeout policy of %s: %s", PluginName, args.TimeoutPolicy)
+		}
+		config.policy = args.TimeoutPolicy
+	}
+	for _, check := range args.Checks {
+		if !check.isValid() {
+			return permitConfig{}, fmt.Errorf("unknown check of %s: %s", PluginName, check)
+		}
+	}
+	if len(args.Checks) > 0 {
+		config.checks = args.Checks
+	}
+	return config, nil
+}
+
+// forPod returns the permit configuration of the pod, its annotations override the arguments of the plugin
+func (c permitConfig) forPod(pod *v1.Pod) permitConfig {
+	if value, ok := pod.Annotations[PermitTimeoutAnnotation]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			klog.Errorf("[Keptn Permit Plugin] invalid permit timeout %s of %s, using %s", value, pod.GetName(), c.timeout)
+		} else {
+			c.timeout = timeout
+		}
+	}
+	if value, ok := pod.Annotations[PermitTimeoutPolicyAnnotation]; ok {
+		if policy := TimeoutPolicy(value); policy.isValid() {
+			c.policy = policy
+		} else {
+			klog.Errorf("[Keptn Permit Plugin] invalid permit timeout policy %s of %s, using %s", value, pod.GetName(), c.policy)
+		}
+	}
+	return c
+}
+
+func (p TimeoutPolicy) isValid() bool {
+	switch p {
+	case TimeoutPolicyReject, TimeoutPolicyAllow, TimeoutPolicyWait:
+		return true
+	}
+	return false
+}
+
+func (c PermitCheck) isValid() bool {
+	_, ok := statusChecks[c]
+	return ok
+}
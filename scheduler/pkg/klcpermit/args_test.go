@@ -0,0 +1,103 @@
+package klcpermit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_parseArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    runtime.Object
+		want    permitConfig
+		wantErr bool
+	}{
+		{
+			name: "no arguments",
+			args: nil,
+			want: permitConfig{timeout: 5 * time.Minute, policy: TimeoutPolicyReject, checks: defaultPermitChecks},
+		},
+		{
+			name: "timeout and policy",
+			args: &runtime.Unknown{Raw: []byte(`{"timeout": "20m", "timeoutPolicy": "wait"}`)},
+			want: permitConfig{timeout: 20 * time.Minute, policy: TimeoutPolicyWait, checks: defaultPermitChecks},
+		},
+		{
+			name: "checks",
+			args: &runtime.Unknown{Raw: []byte(`{"checks": ["appPreDeploymentEvaluations", "workloadPreDeploymentTasks"]}`)},
+			want: permitConfig{
+				timeout: 5 * time.Minute,
+				policy:  TimeoutPolicyReject,
+				checks:  []PermitCheck{CheckAppPreDeploymentEvaluations, CheckWorkloadPreDeploymentTasks},
+			},
+		},
+		{
+			name:    "unknown check",
+			args:    &runtime.Unknown{Raw: []byte(`{"checks": ["postDeploymentTasks"]}`)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			args:    &runtime.Unknown{Raw: []byte(`{"timeoutPolicy": "ignore"}`)},
+			wantErr: true,
+		},
+		{
+			name:    "negative timeout",
+			args:    &runtime.Unknown{Raw: []byte(`{"timeout": "-1m"}`)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseArgs(tt.args)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_permitConfig_forPod(t *testing.T) {
+	config := permitConfig{timeout: 5 * time.Minute, policy: TimeoutPolicyReject}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        permitConfig
+	}{
+		{
+			name: "no annotations",
+			want: config,
+		},
+		{
+			name: "annotations override the arguments",
+			annotations: map[string]string{
+				PermitTimeoutAnnotation:       "20m",
+				PermitTimeoutPolicyAnnotation: "allow",
+			},
+			want: permitConfig{timeout: 20 * time.Minute, policy: TimeoutPolicyAllow},
+		},
+		{
+			name: "invalid annotations are ignored",
+			annotations: map[string]string{
+				PermitTimeoutAnnotation:       "forever",
+				PermitTimeoutPolicyAnnotation: "ignore",
+			},
+			want: config,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			require.Equal(t, tt.want, config.forPod(pod))
+		})
+	}
+}
@@ -2,11 +2,16 @@ package klcpermit
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 )
@@ -20,6 +25,8 @@ const (
 type Permit struct {
 	handler         framework.Handle
 	workloadManager *WorkloadManager
+	podMonitor      *podMonitor
+	config          permitConfig
 }
 
 var _ framework.PermitPlugin = &Permit{}
@@ -34,56 +41,171 @@ func (pl *Permit) Permit(ctx context.Context, state *framework.CycleState, p *v1
 	klog.Infof("[Keptn Permit Plugin] waiting for pre-deployment checks on %s", p.GetObjectMeta().GetName())
 
 	// check the permit immediately, to fail early in case the pod cannot be queued
-	switch pl.workloadManager.Permit(ctx, p) {
+	status, reason := pl.workloadManager.Permit(ctx, p)
+	switch status {
 
 	case Failure:
-		klog.Infof("[Keptn Permit Plugin] failed pre-deployment checks on %s", p.GetObjectMeta().GetName())
-		return framework.NewStatus(framework.Error), 0 * time.Second
+		klog.Infof("[Keptn Permit Plugin] failed pre-deployment checks on %s: %s", p.GetObjectMeta().GetName(), reason)
+		pl.podMonitor.forget(p.UID)
+		return framework.NewStatus(framework.Error, reason), 0 * time.Second
 	case Success:
 		klog.Infof("[Keptn Permit Plugin] passed pre-deployment checks on %s", p.GetObjectMeta().GetName())
+		pl.podMonitor.forget(p.UID)
 		return framework.NewStatus(framework.Success), 0 * time.Second
 	default:
 		klog.Infof("[Keptn Permit Plugin] waiting for pre-deployment checks on %s", p.GetObjectMeta().GetName())
+		// the pod is monitored for as long as the framework lets it wait, the timeout policy applies before that
+		ctx2, cancel := context.WithTimeout(context.Background(), maxPermitWaitDuration)
+		key := getCRDKey(p)
+		monitoredPod := pl.podMonitor.add(key, p, cancel)
 		go func() {
-			// create a new context since we are in a new goroutine
-			ctx2, cancel := context.WithCancel(context.Background())
 			defer cancel()
-			pl.monitorPod(ctx2, p)
+			defer pl.podMonitor.remove(key, monitoredPod)
+			pl.monitorPod(ctx2, monitoredPod, pl.config.forPod(p))
 		}()
-		return framework.NewStatus(framework.Wait), 5 * time.Minute
+		return framework.NewStatus(framework.Wait), maxPermitWaitDuration
 	}
 
 }
 
-func (pl *Permit) monitorPod(ctx context.Context, p *v1.Pod) {
-	waitingPodHandler := pl.handler.GetWaitingPod(p.UID)
+// monitorPod checks the permit of the pod whenever its workload instance changes,
+// until the pod is allowed or rejected, has been deleted or the wait has expired.
+// The timeout counts from the first scheduling cycle the pod waited in, so that it also
+// expires when it is longer than the 15 minutes the framework lets a pod wait at a time.
+func (pl *Permit) monitorPod(ctx context.Context, mp *monitoredPod, config permitConfig) {
+	waitingPodHandler := pl.getWaitingPod(ctx, mp.pod)
+	if waitingPodHandler == nil {
+		return
+	}
+
+	timeout := time.NewTimer(time.Until(mp.waitingSince.Add(config.timeout)))
+	defer timeout.Stop()
 
 	for {
-		switch pl.workloadManager.Permit(ctx, p) {
-		case Failure:
-			waitingPodHandler.Reject(PluginName, "Pre Deployment Check failed")
+		select {
+		case <-ctx.Done():
 			return
-		case Success:
-			waitingPodHandler.Allow(PluginName)
-			return
-		default:
-			time.Sleep(10 * time.Second)
+		case <-timeout.C:
+			switch config.policy {
+			case TimeoutPolicyAllow:
+				klog.Infof("[Keptn Permit Plugin] pre-deployment checks on %s did not finish within %s, allowing it", mp.pod.GetName(), config.timeout)
+				waitingPodHandler.Allow(PluginName)
+				pl.podMonitor.forget(mp.pod.UID)
+				return
+			case TimeoutPolicyWait:
+				klog.Infof("[Keptn Permit Plugin] pre-deployment checks on %s did not finish within %s, still waiting", mp.pod.GetName(), config.timeout)
+			default:
+				waitingPodHandler.Reject(PluginName, fmt.Sprintf("Pre Deployment Check did not finish within %s", config.timeout))
+				pl.podMonitor.forget(mp.pod.UID)
+				return
+			}
+		case <-mp.notify:
+			status, reason := pl.workloadManager.Permit(ctx, mp.pod)
+			switch status {
+			case Failure:
+				waitingPodHandler.Reject(PluginName, fmt.Sprintf("Pre Deployment Check failed: %s", reason))
+				pl.podMonitor.forget(mp.pod.UID)
+				return
+			case Success:
+				waitingPodHandler.Allow(PluginName)
+				pl.podMonitor.forget(mp.pod.UID)
+				return
+			}
 		}
 	}
+}
 
+// getWaitingPod returns the handler of the waiting pod, which the framework registers after the permit returned
+func (pl *Permit) getWaitingPod(ctx context.Context, p *v1.Pod) framework.WaitingPod {
+	for {
+		if waitingPodHandler := pl.handler.GetWaitingPod(p.UID); waitingPodHandler != nil {
+			return waitingPodHandler
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// onWorkloadInstanceChange wakes up the pods that wait for the changed workload instance
+func (pl *Permit) onWorkloadInstanceChange(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("[Keptn Permit Plugin] could not get key of workloadInstance: %s", err.Error())
+		return
+	}
+	pl.podMonitor.notify(key)
+}
+
+// onAppVersionChange wakes up the pods that wait for the workload instances of the changed app version
+func (pl *Permit) onAppVersionChange(obj interface{}) {
+	appVersion, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		klog.Errorf("[Keptn Permit Plugin] unexpected type %T of appVersion", obj)
+		return
+	}
+	for _, key := range getWorkloadInstanceKeys(appVersion) {
+		pl.podMonitor.notify(key)
+	}
+}
+
+// onPodDelete stops monitoring a deleted pod
+func (pl *Permit) onPodDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if pod, ok := obj.(*v1.Pod); ok {
+		pl.podMonitor.cancel(pod.UID)
+	}
 }
 
 // New initializes a new plugin and returns it.
-func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+func New(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	config, err := parseArgs(obj)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := newClient(h)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Permit{
-		workloadManager: NewWorkloadManager(client),
+	plugin := &Permit{
+		workloadManager: NewWorkloadManager(client, config.checks),
 		handler:         h,
-	}, nil
+		podMonitor:      newPodMonitor(),
+		config:          config,
+	}
+
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(client, 0)
+	workloadInstanceInformer := informerFactory.ForResource(workloadInstanceResource)
+	workloadInstanceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: plugin.onWorkloadInstanceChange,
+		UpdateFunc: func(_, newObj interface{}) {
+			plugin.onWorkloadInstanceChange(newObj)
+		},
+	})
+	plugin.workloadManager.lister = workloadInstanceInformer.Lister()
+	if usesAppVersions(config.checks) {
+		appVersionInformer := informerFactory.ForResource(appVersionResource)
+		appVersionInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: plugin.onAppVersionChange,
+			UpdateFunc: func(_, newObj interface{}) {
+				plugin.onAppVersionChange(newObj)
+			},
+		})
+		plugin.workloadManager.appVersionLister = appVersionInformer.Lister()
+	}
+	informerFactory.Start(wait.NeverStop)
+
+	h.SharedInformerFactory().Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: plugin.onPodDelete,
+	})
+
+	return plugin, nil
 }
 
 func newClient(handle framework.Handle) (dynamic.Interface, error) {
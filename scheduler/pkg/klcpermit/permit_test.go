@@ -0,0 +1,336 @@
+package klcpermit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type fakeHandle struct {
+	framework.Handle
+	waitingPods map[types.UID]framework.WaitingPod
+}
+
+func (h *fakeHandle) GetWaitingPod(uid types.UID) framework.WaitingPod {
+	return h.waitingPods[uid]
+}
+
+type fakeWaitingPod struct {
+	framework.WaitingPod
+	result  chan string
+	message string
+}
+
+func (w *fakeWaitingPod) Allow(_ string) {
+	w.result <- "allowed"
+}
+
+func (w *fakeWaitingPod) Reject(_, msg string) {
+	w.message = msg
+	w.result <- "rejected"
+}
+
+func TestPermit_monitorPod(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   string
+	}{
+		{
+			name:   "pod is allowed once the pre-deployment evaluations have succeeded",
+			status: string(StateSucceeded),
+			want:   "allowed",
+		},
+		{
+			name:   "pod is rejected once the pre-deployment evaluations have failed",
+			status: string(StateFailed),
+			want:   "rejected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mypod",
+					Namespace: "default",
+					UID:       "pod1",
+					Labels: map[string]string{
+						WorkloadAnnotation: "myworkload",
+						VersionAnnotation:  "0.0.1",
+						AppAnnotation:      "myapp",
+					},
+				},
+			}
+			workloadInstance := makeWorkloadInstance("default", "myapp-myworkload-0.0.1", string(StateProgressing))
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			require.Nil(t, indexer.Add(workloadInstance))
+
+			waitingPod := &fakeWaitingPod{result: make(chan string, 1)}
+			pl := &Permit{
+				handler: &fakeHandle{waitingPods: map[types.UID]framework.WaitingPod{pod.UID: waitingPod}},
+				workloadManager: &WorkloadManager{
+					lister:      cache.NewGenericLister(indexer, workloadInstanceResource.GroupResource()),
+					checks:      defaultPermitChecks,
+					Tracer:      trace.NewNoopTracerProvider().Tracer("trace"),
+					bindCRDSpan: newSpanCache(100, time.Minute),
+				},
+				podMonitor: newPodMonitor(),
+			}
+
+			ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+			defer cancel()
+			mp := pl.podMonitor.add(getCRDKey(pod), pod, cancel)
+			done := make(chan struct{})
+			go func() {
+				pl.monitorPod(ctx, mp, permitConfig{timeout: time.Minute, policy: TimeoutPolicyReject})
+				close(done)
+			}()
+
+			// the pod keeps waiting while the workload instance is progressing
+			select {
+			case result := <-waitingPod.result:
+				t.Fatalf("unexpected result %s", result)
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			updated := makeWorkloadInstance("default", "myapp-myworkload-0.0.1", tt.status)
+			require.Nil(t, indexer.Update(updated))
+			pl.onWorkloadInstanceChange(updated)
+
+			require.Equal(t, tt.want, <-waitingPod.result)
+			<-done
+		})
+	}
+}
+
+func TestPermit_monitorPodAppVersionChange(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mypod",
+			Namespace: "default",
+			UID:       "pod1",
+			Labels: map[string]string{
+				WorkloadAnnotation: "myworkload",
+				VersionAnnotation:  "0.0.1",
+				AppAnnotation:      "myapp",
+			},
+		},
+	}
+	workloadInstance := makeWorkloadInstance("default", "myapp-myworkload-0.0.1", string(StatePending))
+	appVersion := makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{
+		"preDeploymentEvaluationStatus": string(StateProgressing),
+	})
+
+	workloadInstanceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.Nil(t, workloadInstanceIndexer.Add(workloadInstance))
+	appVersionIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.Nil(t, appVersionIndexer.Add(appVersion))
+
+	waitingPod := &fakeWaitingPod{result: make(chan string, 1)}
+	pl := &Permit{
+		handler: &fakeHandle{waitingPods: map[types.UID]framework.WaitingPod{pod.UID: waitingPod}},
+		workloadManager: &WorkloadManager{
+			lister:           cache.NewGenericLister(workloadInstanceIndexer, workloadInstanceResource.GroupResource()),
+			appVersionLister: cache.NewGenericLister(appVersionIndexer, appVersionResource.GroupResource()),
+			checks:           []PermitCheck{CheckAppPreDeploymentEvaluations, CheckWorkloadPreDeploymentEvaluations},
+			Tracer:           trace.NewNoopTracerProvider().Tracer("trace"),
+			bindCRDSpan:      newSpanCache(100, time.Minute),
+		},
+		podMonitor: newPodMonitor(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+	mp := pl.podMonitor.add(getCRDKey(pod), pod, cancel)
+	done := make(chan struct{})
+	go func() {
+		pl.monitorPod(ctx, mp, permitConfig{timeout: time.Minute, policy: TimeoutPolicyReject})
+		close(done)
+	}()
+
+	// the pod keeps waiting while the app pre-deployment evaluations are progressing
+	select {
+	case result := <-waitingPod.result:
+		t.Fatalf("unexpected result %s", result)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	updated := makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{
+		"preDeploymentEvaluationStatus": string(StateFailed),
+		"preDeploymentEvaluationTaskStatus": []interface{}{
+			map[string]interface{}{"definitionName": "available-cpus", "status": string(StateFailed)},
+		},
+	})
+	require.Nil(t, appVersionIndexer.Update(updated))
+	pl.onAppVersionChange(updated)
+
+	require.Equal(t, "rejected", <-waitingPod.result)
+	require.Equal(t, "Pre Deployment Check failed: pre-deployment evaluation available-cpus of KeptnAppVersion myapp-1.0.0 failed", waitingPod.message)
+	<-done
+}
+
+func TestPermit_monitorPodDeleted(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mypod", Namespace: "default", UID: "pod1"}}
+	pl := &Permit{
+		handler:    &fakeHandle{waitingPods: map[types.UID]framework.WaitingPod{}},
+		podMonitor: newPodMonitor(),
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	mp := pl.podMonitor.add(getCRDKey(pod), pod, cancel)
+	done := make(chan struct{})
+	go func() {
+		pl.monitorPod(ctx, mp, permitConfig{timeout: time.Minute, policy: TimeoutPolicyReject})
+		close(done)
+	}()
+
+	pl.onPodDelete(cache.DeletedFinalStateUnknown{Key: "default/mypod", Obj: pod})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("monitoring of the deleted pod did not stop")
+	}
+}
+
+func TestPermit_monitorPodTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy TimeoutPolicy
+		want   string
+	}{
+		{
+			name:   "pod is rejected after the timeout",
+			policy: TimeoutPolicyReject,
+			want:   "rejected",
+		},
+		{
+			name:   "pod is allowed after the timeout",
+			policy: TimeoutPolicyAllow,
+			want:   "allowed",
+		},
+		{
+			name:   "pod keeps waiting after the timeout",
+			policy: TimeoutPolicyWait,
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mypod", Namespace: "default", UID: "pod1"}}
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			waitingPod := &fakeWaitingPod{result: make(chan string, 1)}
+			pl := &Permit{
+				handler: &fakeHandle{waitingPods: map[types.UID]framework.WaitingPod{pod.UID: waitingPod}},
+				workloadManager: &WorkloadManager{
+					lister: cache.NewGenericLister(indexer, workloadInstanceResource.GroupResource()),
+					checks: defaultPermitChecks,
+				},
+				podMonitor: newPodMonitor(),
+			}
+
+			// the framework stops the wait some time after the timeout of the plugin
+			ctx, cancel := context.WithTimeout(context.TODO(), 500*time.Millisecond)
+			defer cancel()
+			mp := pl.podMonitor.add(getCRDKey(pod), pod, cancel)
+			pl.monitorPod(ctx, mp, permitConfig{timeout: 100 * time.Millisecond, policy: tt.policy})
+
+			result := ""
+			select {
+			case result = <-waitingPod.result:
+			default:
+			}
+			require.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestPermit_monitorPodTimeoutAcrossCycles(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mypod", Namespace: "default", UID: "pod1"}}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	waitingPod := &fakeWaitingPod{result: make(chan string, 1)}
+	pl := &Permit{
+		handler: &fakeHandle{waitingPods: map[types.UID]framework.WaitingPod{pod.UID: waitingPod}},
+		workloadManager: &WorkloadManager{
+			lister: cache.NewGenericLister(indexer, workloadInstanceResource.GroupResource()),
+			checks: defaultPermitChecks,
+		},
+		podMonitor: newPodMonitor(),
+	}
+	config := permitConfig{timeout: 300 * time.Millisecond, policy: TimeoutPolicyReject}
+
+	// the framework stops the wait before the timeout of the plugin has expired and requeues the pod
+	ctx, cancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+	defer cancel()
+	mp := pl.podMonitor.add(getCRDKey(pod), pod, cancel)
+	pl.monitorPod(ctx, mp, config)
+	pl.podMonitor.remove(getCRDKey(pod), mp)
+	require.Len(t, waitingPod.result, 0)
+
+	// the timeout expires in the next scheduling cycle of the pod
+	ctx2, cancel2 := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+	defer cancel2()
+	mp = pl.podMonitor.add(getCRDKey(pod), pod, cancel2)
+	pl.monitorPod(ctx2, mp, config)
+	require.Len(t, waitingPod.result, 1)
+	require.Equal(t, "rejected", <-waitingPod.result)
+	require.Equal(t, "Pre Deployment Check did not finish within 300ms", waitingPod.message)
+}
+
+func makeWorkloadInstance(namespace, name, preDeploymentEvaluationStatus string) *unstructured.Unstructured {
+	return makeWorkloadInstanceWithStatus(namespace, name, map[string]interface{}{
+		"preDeploymentEvaluationStatus": preDeploymentEvaluationStatus,
+	})
+}
+
+// makeWorkloadInstanceWithStatus returns the workload instance of version 0.0.1 of myworkload of myapp
+func makeWorkloadInstanceWithStatus(namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "lifecycle.keptn.sh/v1alpha2",
+			"kind":       "KeptnWorkloadInstance",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"app":          "myapp",
+				"workloadName": "myapp-myworkload",
+				"version":      "0.0.1",
+			},
+			"status": status,
+		},
+	}
+}
+
+// makeAppVersion returns an app version of myapp that contains version 0.0.1 of myworkload
+func makeAppVersion(namespace, name, version string, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "lifecycle.keptn.sh/v1alpha2",
+			"kind":       "KeptnAppVersion",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"appName": "myapp",
+				"version": version,
+				"workloads": []interface{}{
+					map[string]interface{}{"name": "myworkload", "version": "0.0.1"},
+				},
+			},
+			"status": status,
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package klcpermit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// monitoredPod is a pod that waits for the status of its workload instance to change
+type monitoredPod struct {
+	pod *v1.Pod
+	// notify receives a value whenever the workload instance of the pod has changed
+	notify chan struct{}
+	cancel context.CancelFunc
+	// waitingSince is when the pod started to wait in its first scheduling cycle
+	waitingSince time.Time
+}
+
+// podMonitor keeps track of the waiting pods by the workload instance they wait for,
+// and of when they started to wait, because the scheduler framework requeues a pod after 15 minutes
+type podMonitor struct {
+	mtx          sync.Mutex
+	pods         map[string]map[types.UID]*monitoredPod
+	waitingSince map[types.UID]time.Time
+}
+
+func newPodMonitor() *podMonitor {
+	return &podMonitor{
+		pods:         make(map[string]map[types.UID]*monitoredPod),
+		waitingSince: make(map[types.UID]time.Time),
+	}
+}
+
+// add registers a waiting pod for the workload instance with the given key,
+// the pod is notified once right away so that changes that happened before are not missed.
+// A pod that is still monitored from a previous scheduling cycle stops being monitored.
+func (m *podMonitor) add(key string, pod *v1.Pod, cancel context.CancelFunc) *monitoredPod {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	waitingSince, ok := m.waitingSince[pod.UID]
+	if !ok {
+		waitingSince = time.Now()
+		m.waitingSince[pod.UID] = waitingSince
+	}
+
+	mp := &monitoredPod{
+		pod:          pod,
+		notify:       make(chan struct{}, 1),
+		cancel:       cancel,
+		waitingSince: waitingSince,
+	}
+	mp.notify <- struct{}{}
+
+	if _, ok := m.pods[key]; !ok {
+		m.pods[key] = make(map[types.UID]*monitoredPod)
+	}
+	if previous, ok := m.pods[key][pod.UID]; ok {
+		previous.cancel()
+	}
+	m.pods[key][pod.UID] = mp
+	return mp
+}
+
+// remove unregisters a waiting pod, unless it has been replaced by a later scheduling cycle of the same pod
+func (m *podMonitor) remove(key string, mp *monitoredPod) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.pods[key][mp.pod.UID] != mp {
+		return
+	}
+	delete(m.pods[key], mp.pod.UID)
+	if len(m.pods[key]) == 0 {
+		delete(m.pods, key)
+	}
+}
+
+// forget drops the time the pod with the given UID started to wait, once it has been allowed or rejected
+func (m *podMonitor) forget(uid types.UID) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.waitingSince, uid)
+}
+
+// notify wakes up all pods that wait for the workload instance with the given key
+func (m *podMonitor) notify(key string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, mp := range m.pods[key] {
+		// a pending notification is enough, the pod checks the latest status anyway
+		select {
+		case mp.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// cancel stops waiting for the pod with the given UID, e.g. because it has been deleted
+func (m *podMonitor) cancel(uid types.UID) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.waitingSince, uid)
+	for _, pods := range m.pods {
+		if mp, ok := pods[uid]; ok {
+			mp.cancel()
+			return
+		}
+	}
+}
+
+// count returns the number of waiting pods
+func (m *podMonitor) count() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	count := 0
+	for _, pods := range m.pods {
+		count += len(pods)
+	}
+	return count
+}
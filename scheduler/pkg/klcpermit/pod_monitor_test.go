@@ -0,0 +1,72 @@
+package klcpermit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_podMonitor(t *testing.T) {
+	m := newPodMonitor()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod1"}}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod2"}}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	mp := m.add("default/myapp-myworkload-0.0.1", pod, cancel)
+	mp2 := m.add("default/myapp-myworkload-0.0.2", pod2, func() {})
+	require.Equal(t, 2, m.count())
+
+	// a new pod is notified right away
+	require.Len(t, mp.notify, 1)
+	<-mp.notify
+	<-mp2.notify
+
+	// only the pods of the workload instance are notified, at most once until they handle the notification
+	m.notify("default/myapp-myworkload-0.0.1")
+	m.notify("default/myapp-myworkload-0.0.1")
+	require.Len(t, mp.notify, 1)
+	require.Len(t, mp2.notify, 0)
+
+	m.cancel("pod1")
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	m.remove("default/myapp-myworkload-0.0.1", mp)
+	require.Equal(t, 1, m.count())
+	m.remove("default/myapp-myworkload-0.0.2", mp2)
+	require.Equal(t, 0, m.count())
+	require.Empty(t, m.pods)
+}
+
+func Test_podMonitorRequeuedPod(t *testing.T) {
+	m := newPodMonitor()
+	key := "default/myapp-myworkload-0.0.1"
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod1"}}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	previous := m.add(key, pod, cancel)
+
+	// the pod is monitored again in a later scheduling cycle, which stops the previous monitor
+	current := m.add(key, pod, func() {})
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.Equal(t, 1, m.count())
+
+	// the previous monitor does not unregister the current one
+	m.remove(key, previous)
+	require.Equal(t, 1, m.count())
+	require.Same(t, current, m.pods[key][pod.UID])
+
+	// the pod still waits since its first scheduling cycle
+	require.Equal(t, previous.waitingSince, current.waitingSince)
+
+	m.remove(key, current)
+	require.Equal(t, 0, m.count())
+
+	// once the pod has been allowed or rejected, it waits anew
+	m.forget(pod.UID)
+	next := m.add(key, pod, func() {})
+	require.True(t, next.waitingSince.After(previous.waitingSince))
+}
@@ -0,0 +1,113 @@
+package klcpermit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanCacheSize is the maximum number of spans that are kept at the same time
+const spanCacheSize = 1000
+
+// spanCacheTTL is the time after which a span that has not been used is evicted. A waiting pod uses the span
+// of its workload instance at least once per scheduling cycle, which lasts at most 15 minutes.
+const spanCacheTTL = 20 * time.Minute
+
+type cachedSpan struct {
+	span    trace.Span
+	expires time.Time
+}
+
+// spanCache is a concurrency-safe cache of the spans of the workload instances that pods wait for.
+// It only keeps sampled spans, and ends the spans it evicts because they have not been used for the TTL
+// or the cache is full.
+type spanCache struct {
+	mtx     sync.Mutex
+	spans   map[string]cachedSpan
+	maxSize int
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func newSpanCache(maxSize int, ttl time.Duration) *spanCache {
+	return &spanCache{
+		spans:   make(map[string]cachedSpan, maxSize),
+		maxSize: maxSize,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// getOrCreate returns the span with the given key, or creates one if there is none.
+// Every use of a span postpones its expiry, so that the spans of pods that are still waiting are kept.
+func (c *spanCache) getOrCreate(ctx context.Context, key string, create func() (context.Context, trace.Span)) (context.Context, trace.Span) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.evictExpired()
+	if cached, ok := c.spans[key]; ok {
+		cached.expires = c.now().Add(c.ttl)
+		c.spans[key] = cached
+		return ctx, cached.span
+	}
+
+	ctx, span := create()
+	if !span.SpanContext().IsSampled() {
+		return ctx, span
+	}
+	if len(c.spans) >= c.maxSize {
+		c.evictOldest()
+	}
+	c.spans[key] = cachedSpan{
+		span:    span,
+		expires: c.now().Add(c.ttl),
+	}
+	return ctx, span
+}
+
+// remove removes the span with the given key from the cache without ending it
+func (c *spanCache) remove(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.spans, key)
+}
+
+func (c *spanCache) len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.spans)
+}
+
+func (c *spanCache) evictExpired() {
+	now := c.now()
+	for key, cached := range c.spans {
+		if now.After(cached.expires) {
+			c.evict(key, cached)
+		}
+	}
+}
+
+func (c *spanCache) evictOldest() {
+	oldestKey := ""
+	var oldest cachedSpan
+	for key, cached := range c.spans {
+		if oldestKey == "" || cached.expires.Before(oldest.expires) {
+			oldestKey, oldest = key, cached
+		}
+	}
+	if oldestKey != "" {
+		c.evict(oldestKey, oldest)
+	}
+}
+
+func (c *spanCache) evict(key string, cached cachedSpan) {
+	cached.span.AddEvent("Evicted")
+	cached.span.SetStatus(codes.Unset, "pod stopped waiting before the pre-deployment checks finished")
+	cached.span.End()
+	delete(c.spans, key)
+}
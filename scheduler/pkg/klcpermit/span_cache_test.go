@@ -0,0 +1,94 @@
+package klcpermit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_spanCache(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample())).Tracer("trace")
+	create := func() (context.Context, trace.Span) {
+		return tracer.Start(context.TODO(), "span")
+	}
+
+	start := time.Now()
+	now := start
+	c := newSpanCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, span1 := c.getOrCreate(context.TODO(), "span1", create)
+	now = now.Add(time.Second)
+	_, span2 := c.getOrCreate(context.TODO(), "span2", create)
+	require.Equal(t, 2, c.len())
+
+	// using a span postpones its expiry
+	now = now.Add(time.Second)
+	_, cached := c.getOrCreate(context.TODO(), "span1", create)
+	require.Same(t, span1, cached)
+
+	// the least recently used span is evicted and ended when the cache is full
+	now = now.Add(time.Second)
+	_, span3 := c.getOrCreate(context.TODO(), "span3", create)
+	require.Equal(t, 2, c.len())
+	require.False(t, span2.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+	require.True(t, span1.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+
+	// expired spans are evicted and ended
+	now = start.Add(2*time.Second + time.Minute + 500*time.Millisecond)
+	_, _ = c.getOrCreate(context.TODO(), "span4", create)
+	require.Equal(t, 2, c.len())
+	require.False(t, span1.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+	require.True(t, span3.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+
+	// a span that is used regularly is kept longer than the TTL
+	for i := 0; i < 3; i++ {
+		_, cached = c.getOrCreate(context.TODO(), "span3", create)
+		require.Same(t, span3, cached)
+		now = now.Add(50 * time.Second)
+	}
+	require.True(t, span3.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+	require.Equal(t, 1, c.len())
+
+	// removed spans are not ended
+	c.remove("span3")
+	require.Equal(t, 0, c.len())
+	require.True(t, span3.(sdktrace.ReadOnlySpan).EndTime().IsZero())
+}
+
+func Test_spanCacheNotSampled(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample())).Tracer("trace")
+	c := newSpanCache(2, time.Minute)
+
+	_, span := c.getOrCreate(context.TODO(), "span", func() (context.Context, trace.Span) {
+		return tracer.Start(context.TODO(), "span")
+	})
+	require.NotNil(t, span)
+	require.Equal(t, 0, c.len())
+}
+
+func Test_spanCacheConcurrency(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample())).Tracer("trace")
+	c := newSpanCache(10, time.Minute)
+
+	done := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		go func(i int) {
+			key := fmt.Sprintf("span%d", i%5)
+			_, _ = c.getOrCreate(context.TODO(), key, func() (context.Context, trace.Span) {
+				return tracer.Start(context.TODO(), key)
+			})
+			c.remove(fmt.Sprintf("span%d", i%3))
+			done <- struct{}{}
+		}(i)
+	}
+	for i := 0; i < 20; i++ {
+		<-done
+	}
+	require.LessOrEqual(t, c.len(), 5)
+}
@@ -0,0 +1,252 @@
+package klcpermit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/keptn/lifecycle-toolkit/scheduler/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var appVersionResource = schema.GroupVersionResource{Group: "lifecycle.keptn.sh", Version: "v1alpha2", Resource: "keptnappversions"}
+
+// statusCheck tells where the overall state of a check and the states of its single tasks or evaluations
+// are found in the status of the workload instance or app version
+type statusCheck struct {
+	app         bool
+	stateField  string
+	itemsField  string
+	description string
+}
+
+var statusChecks = map[PermitCheck]statusCheck{
+	CheckAppPreDeploymentTasks: {
+		app:         true,
+		stateField:  "preDeploymentStatus",
+		itemsField:  "preDeploymentTaskStatus",
+		description: "pre-deployment task",
+	},
+	CheckAppPreDeploymentEvaluations: {
+		app:         true,
+		stateField:  "preDeploymentEvaluationStatus",
+		itemsField:  "preDeploymentEvaluationTaskStatus",
+		description: "pre-deployment evaluation",
+	},
+	CheckWorkloadPreDeploymentTasks: {
+		stateField:  "preDeploymentStatus",
+		itemsField:  "preDeploymentTaskStatus",
+		description: "pre-deployment task",
+	},
+	CheckWorkloadPreDeploymentEvaluations: {
+		stateField:  "preDeploymentEvaluationStatus",
+		itemsField:  "preDeploymentEvaluationTaskStatus",
+		description: "pre-deployment evaluation",
+	},
+}
+
+// usesAppVersions tells whether any of the checks reads the app version of a pod
+func usesAppVersions(checks []PermitCheck) bool {
+	for _, check := range checks {
+		if statusChecks[check].app {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateChecks evaluates the checks of the workload instance in order. A failed check fails the permit,
+// otherwise the first check that has not succeeded yet blocks it. The reason names the failed or blocking
+// task or evaluation.
+func (sMgr *WorkloadManager) evaluateChecks(ctx context.Context, workloadInstance *unstructured.Unstructured, span trace.Span) (Status, string) {
+	var appVersion *unstructured.Unstructured
+	blockingStatus, blockingReason := Success, ""
+
+	for _, check := range sMgr.checks {
+		sc := statusChecks[check]
+		obj := workloadInstance
+		if sc.app {
+			if appVersion == nil {
+				var err error
+				appVersion, err = sMgr.getAppVersion(ctx, workloadInstance)
+				if err != nil {
+					// the app version might not have been created yet
+					if blockingStatus == Success {
+						blockingStatus, blockingReason = Wait, err.Error()
+					}
+					continue
+				}
+			}
+			obj = appVersion
+		}
+
+		state, found, err := unstructured.NestedString(obj.UnstructuredContent(), "status", sc.stateField)
+		if err != nil || !found {
+			if blockingStatus == Success {
+				blockingStatus, blockingReason = WorkloadInstanceStatusNotSpecified, fmt.Sprintf("state of the %ss of %s %s is not specified", sc.description, obj.GetKind(), obj.GetName())
+			}
+			continue
+		}
+		span.AddEvent("StatusEvaluation", trace.WithAttributes(tracing.PermitCheck.String(string(check)), tracing.Status.String(state)))
+
+		switch KeptnState(state) {
+		case StateFailed, StateDeprecated:
+			return Failure, failureReason(obj, sc, state)
+		case StateSucceeded:
+		default:
+			if blockingStatus == Success {
+				blockingStatus, blockingReason = Wait, waitReason(obj, sc)
+			}
+		}
+	}
+	return blockingStatus, blockingReason
+}
+
+// failureReason names the first failed task or evaluation of the check
+func failureReason(obj *unstructured.Unstructured, sc statusCheck, state string) string {
+	for _, item := range getItems(obj, sc) {
+		if item.state == StateFailed {
+			return fmt.Sprintf("%s %s of %s %s failed", sc.description, item.definitionName, obj.GetKind(), obj.GetName())
+		}
+	}
+	return fmt.Sprintf("%ss of %s %s have state %s", sc.description, obj.GetKind(), obj.GetName(), state)
+}
+
+// waitReason names the first task or evaluation of the check that has not succeeded yet
+func waitReason(obj *unstructured.Unstructured, sc statusCheck) string {
+	for _, item := range getItems(obj, sc) {
+		if item.state != StateSucceeded {
+			return fmt.Sprintf("waiting for %s %s of %s %s", sc.description, item.definitionName, obj.GetKind(), obj.GetName())
+		}
+	}
+	return fmt.Sprintf("waiting for %ss of %s %s", sc.description, obj.GetKind(), obj.GetName())
+}
+
+type itemStatus struct {
+	definitionName string
+	state          KeptnState
+}
+
+func getItems(obj *unstructured.Unstructured, sc statusCheck) []itemStatus {
+	items, _, _ := unstructured.NestedSlice(obj.UnstructuredContent(), "status", sc.itemsField)
+	result := make([]itemStatus, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		definitionName, _, _ := unstructured.NestedString(fields, "definitionName")
+		state, _, _ := unstructured.NestedString(fields, "status")
+		result = append(result, itemStatus{definitionName: definitionName, state: KeptnState(state)})
+	}
+	return result
+}
+
+// getAppVersion returns the latest app version that is not deprecated and contains the workload instance,
+// the same way the operator looks it up
+func (sMgr *WorkloadManager) getAppVersion(ctx context.Context, workloadInstance *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	appName, _, _ := unstructured.NestedString(workloadInstance.UnstructuredContent(), "spec", "app")
+
+	appVersions, err := sMgr.listAppVersions(ctx, workloadInstance.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("could not list app versions of %s: %w", appName, err)
+	}
+
+	var latest *unstructured.Unstructured
+	var latestVersion *version.Version
+	for _, appVersion := range appVersions {
+		if !appVersionContainsWorkloadInstance(appVersion, workloadInstance) {
+			continue
+		}
+		appVersionName, _, _ := unstructured.NestedString(appVersion.UnstructuredContent(), "spec", "version")
+		v, err := version.NewVersion(appVersionName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of app version %s: %w", appVersion.GetName(), err)
+		}
+		if latest == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = appVersion, v
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no app version of %s contains %s", appName, workloadInstance.GetName())
+	}
+	return latest, nil
+}
+
+func (sMgr *WorkloadManager) listAppVersions(ctx context.Context, namespace string) ([]*unstructured.Unstructured, error) {
+	if sMgr.appVersionLister != nil {
+		objs, err := sMgr.appVersionLister.ByNamespace(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		appVersions := make([]*unstructured.Unstructured, 0, len(objs))
+		for _, obj := range objs {
+			if appVersion, ok := obj.(*unstructured.Unstructured); ok {
+				appVersions = append(appVersions, appVersion)
+			}
+		}
+		return appVersions, nil
+	}
+	list, err := sMgr.dynamicClient.Resource(appVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	appVersions := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		appVersions = append(appVersions, &list.Items[i])
+	}
+	return appVersions, nil
+}
+
+func appVersionContainsWorkloadInstance(appVersion *unstructured.Unstructured, workloadInstance *unstructured.Unstructured) bool {
+	state, _, _ := unstructured.NestedString(appVersion.UnstructuredContent(), "status", "status")
+	appName, _, _ := unstructured.NestedString(appVersion.UnstructuredContent(), "spec", "appName")
+	workloadAppName, _, _ := unstructured.NestedString(workloadInstance.UnstructuredContent(), "spec", "app")
+	if KeptnState(state) == StateDeprecated || appName != workloadAppName {
+		return false
+	}
+
+	workloadName, _, _ := unstructured.NestedString(workloadInstance.UnstructuredContent(), "spec", "workloadName")
+	workloadVersion, _, _ := unstructured.NestedString(workloadInstance.UnstructuredContent(), "spec", "version")
+	for _, workload := range getAppWorkloads(appVersion) {
+		if appName+"-"+workload.name == workloadName && workload.version == workloadVersion {
+			return true
+		}
+	}
+	return false
+}
+
+type appWorkload struct {
+	name    string
+	version string
+}
+
+func getAppWorkloads(appVersion *unstructured.Unstructured) []appWorkload {
+	workloads, _, _ := unstructured.NestedSlice(appVersion.UnstructuredContent(), "spec", "workloads")
+	result := make([]appWorkload, 0, len(workloads))
+	for _, workload := range workloads {
+		fields, ok := workload.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(fields, "name")
+		workloadVersion, _, _ := unstructured.NestedString(fields, "version")
+		result = append(result, appWorkload{name: name, version: workloadVersion})
+	}
+	return result
+}
+
+// getWorkloadInstanceKeys returns the namespace/name keys of the workload instances of the app version
+func getWorkloadInstanceKeys(appVersion *unstructured.Unstructured) []string {
+	appName, _, _ := unstructured.NestedString(appVersion.UnstructuredContent(), "spec", "appName")
+	workloads := getAppWorkloads(appVersion)
+	keys := make([]string, 0, len(workloads))
+	for _, workload := range workloads {
+		keys = append(keys, appVersion.GetNamespace()+"/"+appName+"-"+workload.name+"-"+workload.version)
+	}
+	return keys
+}
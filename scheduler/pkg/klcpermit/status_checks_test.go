@@ -0,0 +1,183 @@
+package klcpermit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWorkloadManager_Permit(t *testing.T) {
+	allChecks := []PermitCheck{
+		CheckAppPreDeploymentTasks,
+		CheckAppPreDeploymentEvaluations,
+		CheckWorkloadPreDeploymentTasks,
+		CheckWorkloadPreDeploymentEvaluations,
+	}
+	appSucceeded := map[string]interface{}{
+		"preDeploymentStatus":           string(StateSucceeded),
+		"preDeploymentEvaluationStatus": string(StateSucceeded),
+	}
+
+	tests := []struct {
+		name             string
+		checks           []PermitCheck
+		workloadInstance *unstructured.Unstructured
+		appVersions      []*unstructured.Unstructured
+		wantStatus       Status
+		wantReason       string
+	}{
+		{
+			name:       "workload instance not found",
+			checks:     defaultPermitChecks,
+			wantStatus: WorkloadInstanceNotFound,
+			wantReason: "workloadInstance myapp-myworkload-0.0.1 not found",
+		},
+		{
+			name:   "all checks succeeded",
+			checks: allChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentStatus":           string(StateSucceeded),
+				"preDeploymentEvaluationStatus": string(StateSucceeded),
+			}),
+			appVersions: []*unstructured.Unstructured{makeAppVersion("default", "myapp-1.0.0", "1.0.0", appSucceeded)},
+			wantStatus:  Success,
+		},
+		{
+			name:   "failed workload pre-deployment task is named",
+			checks: allChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentStatus":           string(StateFailed),
+				"preDeploymentEvaluationStatus": string(StatePending),
+				"preDeploymentTaskStatus": []interface{}{
+					map[string]interface{}{"definitionName": "migrate-db", "status": string(StateSucceeded)},
+					map[string]interface{}{"definitionName": "check-db", "status": string(StateFailed)},
+				},
+			}),
+			appVersions: []*unstructured.Unstructured{makeAppVersion("default", "myapp-1.0.0", "1.0.0", appSucceeded)},
+			wantStatus:  Failure,
+			wantReason:  "pre-deployment task check-db of KeptnWorkloadInstance myapp-myworkload-0.0.1 failed",
+		},
+		{
+			name:   "failed app pre-deployment evaluation is named",
+			checks: allChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentStatus":           string(StatePending),
+				"preDeploymentEvaluationStatus": string(StatePending),
+			}),
+			appVersions: []*unstructured.Unstructured{
+				makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{
+					"preDeploymentStatus":           string(StateSucceeded),
+					"preDeploymentEvaluationStatus": string(StateFailed),
+					"preDeploymentEvaluationTaskStatus": []interface{}{
+						map[string]interface{}{"definitionName": "available-cpus", "status": string(StateFailed)},
+					},
+				}),
+			},
+			wantStatus: Failure,
+			wantReason: "pre-deployment evaluation available-cpus of KeptnAppVersion myapp-1.0.0 failed",
+		},
+		{
+			name:   "first check that has not succeeded blocks the pod",
+			checks: allChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentStatus":           string(StatePending),
+				"preDeploymentEvaluationStatus": string(StatePending),
+			}),
+			appVersions: []*unstructured.Unstructured{
+				makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{
+					"preDeploymentStatus":           string(StateProgressing),
+					"preDeploymentEvaluationStatus": string(StatePending),
+					"preDeploymentTaskStatus": []interface{}{
+						map[string]interface{}{"definitionName": "notify", "status": string(StateSucceeded)},
+						map[string]interface{}{"definitionName": "create-ticket", "status": string(StateProgressing)},
+					},
+				}),
+			},
+			wantStatus: Wait,
+			wantReason: "waiting for pre-deployment task create-ticket of KeptnAppVersion myapp-1.0.0",
+		},
+		{
+			name:   "latest app version that contains the workload instance is checked",
+			checks: []PermitCheck{CheckAppPreDeploymentEvaluations},
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentEvaluationStatus": string(StatePending),
+			}),
+			appVersions: []*unstructured.Unstructured{
+				makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{
+					"preDeploymentEvaluationStatus": string(StateFailed),
+				}),
+				makeAppVersion("default", "myapp-1.1.0", "1.1.0", appSucceeded),
+				makeAppVersion("default", "myapp-2.0.0", "2.0.0", map[string]interface{}{
+					"status":                        string(StateDeprecated),
+					"preDeploymentEvaluationStatus": string(StateFailed),
+				}),
+			},
+			wantStatus: Success,
+		},
+		{
+			name:   "pod waits for its app version",
+			checks: allChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{
+				"preDeploymentStatus":           string(StatePending),
+				"preDeploymentEvaluationStatus": string(StatePending),
+			}),
+			wantStatus: Wait,
+			wantReason: "no app version of myapp contains myapp-myworkload-0.0.1",
+		},
+		{
+			name:             "status not specified",
+			checks:           defaultPermitChecks,
+			workloadInstance: makeWorkloadInstanceWithStatus("default", "myapp-myworkload-0.0.1", map[string]interface{}{}),
+			wantStatus:       WorkloadInstanceStatusNotSpecified,
+			wantReason:       "state of the pre-deployment evaluations of KeptnWorkloadInstance myapp-myworkload-0.0.1 is not specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mypod",
+					Namespace: "default",
+					Labels: map[string]string{
+						WorkloadAnnotation: "myworkload",
+						VersionAnnotation:  "0.0.1",
+						AppAnnotation:      "myapp",
+					},
+				},
+			}
+
+			workloadInstanceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tt.workloadInstance != nil {
+				require.Nil(t, workloadInstanceIndexer.Add(tt.workloadInstance))
+			}
+			appVersionIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, appVersion := range tt.appVersions {
+				require.Nil(t, appVersionIndexer.Add(appVersion))
+			}
+
+			sMgr := &WorkloadManager{
+				lister:           cache.NewGenericLister(workloadInstanceIndexer, workloadInstanceResource.GroupResource()),
+				appVersionLister: cache.NewGenericLister(appVersionIndexer, appVersionResource.GroupResource()),
+				checks:           tt.checks,
+				Tracer:           trace.NewNoopTracerProvider().Tracer("trace"),
+				bindCRDSpan:      newSpanCache(100, time.Minute),
+			}
+
+			status, reason := sMgr.Permit(context.TODO(), pod)
+			require.Equal(t, tt.wantStatus, status)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_getWorkloadInstanceKeys(t *testing.T) {
+	appVersion := makeAppVersion("default", "myapp-1.0.0", "1.0.0", map[string]interface{}{})
+	require.Equal(t, []string{"default/myapp-myworkload-0.0.1"}, getWorkloadInstanceKeys(appVersion))
+}
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 )
 
@@ -49,77 +50,81 @@ const K8sRecommendedVersionAnnotations = "app.kubernetes.io/version"
 const K8sRecommendedAppAnnotations = "app.kubernetes.io/part-of"
 
 type Manager interface {
-	Permit(context.Context, *corev1.Pod) Status
+	Permit(context.Context, *corev1.Pod) (Status, string)
 }
 
 type WorkloadManager struct {
 	dynamicClient dynamic.Interface
-	Tracer        trace.Tracer
-	bindCRDSpan   map[string]trace.Span
+	// lister reads the workload instances from the cache of the shared informer, if it is set
+	lister cache.GenericLister
+	// appVersionLister reads the app versions from the cache of the shared informer, if it is set
+	appVersionLister cache.GenericLister
+	// checks are evaluated in order to decide whether a pod is permitted
+	checks      []PermitCheck
+	Tracer      trace.Tracer
+	bindCRDSpan *spanCache
 }
 
-func NewWorkloadManager(d dynamic.Interface) *WorkloadManager {
+func NewWorkloadManager(d dynamic.Interface, checks []PermitCheck) *WorkloadManager {
 	sMgr := &WorkloadManager{
 		dynamicClient: d,
+		checks:        checks,
 		Tracer:        otel.Tracer("keptn/scheduler"),
-		bindCRDSpan:   make(map[string]trace.Span, 100),
+		bindCRDSpan:   newSpanCache(spanCacheSize, spanCacheTTL),
 	}
 	return sMgr
 }
 
-func (sMgr *WorkloadManager) Permit(ctx context.Context, pod *corev1.Pod) Status {
+// Permit evaluates the checks of the pod, the returned reason names the task or evaluation that blocks
+// or failed the pod
+func (sMgr *WorkloadManager) Permit(ctx context.Context, pod *corev1.Pod) (Status, string) {
 	//List workloadInstance run CRDs
 	name := getCRDName(pod)
 	crd, err := sMgr.GetCRD(ctx, pod.Namespace, name)
 
 	if err != nil {
 		klog.Infof("[Keptn Permit Plugin] could not find workloadInstance crd %s, err:%s", name, err.Error())
-		return WorkloadInstanceNotFound
+		return WorkloadInstanceNotFound, fmt.Sprintf("workloadInstance %s not found", name)
 	}
 
 	_, span := sMgr.getSpan(ctx, crd, pod)
 
 	//check CRD status
-	phase, found, err := unstructured.NestedString(crd.UnstructuredContent(), "status", "preDeploymentEvaluationStatus")
-	klog.Infof("[Keptn Permit Plugin] workloadInstance crd %s, found %s with phase %s ", crd, found, phase)
-	if err == nil && found {
-		span.AddEvent("StatusEvaluation", trace.WithAttributes(tracing.Status.String(phase)))
-		switch KeptnState(phase) {
-		case StateFailed, StateDeprecated:
-			span.SetStatus(codes.Error, "Failed")
-			span.End()
-			sMgr.unbindSpan(pod)
-			return Failure
-		case StateSucceeded:
-			span.End()
-			sMgr.unbindSpan(pod)
-			return Success
-		case StatePending:
-			return Wait
-		case StateProgressing:
-			return Wait
-		case StateUnknown:
-			return Wait
-		}
+	status, reason := sMgr.evaluateChecks(ctx, crd, span)
+	klog.Infof("[Keptn Permit Plugin] workloadInstance crd %s has status %s: %s", name, status, reason)
+	switch status {
+	case Failure:
+		span.SetStatus(codes.Error, reason)
+		span.End()
+		sMgr.unbindSpan(pod)
+	case Success:
+		span.End()
+		sMgr.unbindSpan(pod)
 	}
-	return WorkloadInstanceStatusNotSpecified
+	return status, reason
 }
 
 // GetCRD returns unstructured to avoid tight coupling with the CRD resource
 func (sMgr *WorkloadManager) GetCRD(ctx context.Context, namespace string, name string) (*unstructured.Unstructured, error) {
+	if sMgr.lister != nil {
+		obj, err := sMgr.lister.ByNamespace(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		crd, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of workloadInstance %s", obj, name)
+		}
+		return crd, nil
+	}
 	// GET /apis/lifecycle.keptn.sh/v1/namespaces/{namespace}/workloadinstance/name
 	return sMgr.dynamicClient.Resource(workloadInstanceResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 func (sMgr *WorkloadManager) getSpan(ctx context.Context, crd *unstructured.Unstructured, pod *corev1.Pod) (context.Context, trace.Span) {
-	name := getCRDName(pod)
-	if span, ok := sMgr.bindCRDSpan[name]; ok {
-		return ctx, span
-	}
-	ctx, span := tracing.CreateSpan(ctx, crd, sMgr.Tracer, pod.Namespace)
-	//TODO store only sampled one and cap it
-	sMgr.bindCRDSpan[name] = span
-	return ctx, span
+	return sMgr.bindCRDSpan.getOrCreate(ctx, getCRDKey(pod), func() (context.Context, trace.Span) {
+		return tracing.CreateSpan(ctx, crd, sMgr.Tracer, pod.Namespace)
+	})
 }
 
 func getCRDName(pod *corev1.Pod) string {
@@ -132,9 +137,13 @@ func getCRDName(pod *corev1.Pod) string {
 	return application + "-" + workloadInstance + "-" + version
 }
 
+// getCRDKey returns the namespace/name key of the workloadInstance of the pod, as used by the informer cache
+func getCRDKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + getCRDName(pod)
+}
+
 func (sMgr *WorkloadManager) unbindSpan(pod *corev1.Pod) {
-	name := getCRDName(pod)
-	delete(sMgr.bindCRDSpan, name)
+	sMgr.bindCRDSpan.remove(getCRDKey(pod))
 }
 
 func getLabelOrAnnotation(pod *corev1.Pod, primaryAnnotation string, secondaryAnnotation string) (string, bool) {
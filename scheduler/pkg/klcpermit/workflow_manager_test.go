@@ -3,9 +3,10 @@ package klcpermit
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/otel/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -218,58 +219,85 @@ func Test_getSpan_unbindSpan(t *testing.T) {
 	}
 
 	r := &WorkloadManager{
-		bindCRDSpan: make(map[string]trace.Span, 100),
-		Tracer:      trace.NewNoopTracerProvider().Tracer("trace"),
+		bindCRDSpan: newSpanCache(100, time.Minute),
+		Tracer:      sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample())).Tracer("trace"),
 	}
 
 	// create span for first pod
 	_, span := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod)
 
 	require.NotNil(t, span)
-	require.Len(t, r.bindCRDSpan, 1)
+	require.Equal(t, 1, r.bindCRDSpan.len())
 
 	// fetch the created span for first pod
 	_, span2 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod)
 
 	require.Equal(t, span, span2)
-	require.Len(t, r.bindCRDSpan, 1)
+	require.Equal(t, 1, r.bindCRDSpan.len())
 
 	// create another span for second pod
 	_, span3 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod2)
 
 	require.NotNil(t, span3)
-	require.Len(t, r.bindCRDSpan, 2)
+	require.Equal(t, 2, r.bindCRDSpan.len())
 
 	// fetch the created span for second pod
 	_, span4 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod2)
 
 	require.Equal(t, span3, span4)
-	require.Len(t, r.bindCRDSpan, 2)
+	require.Equal(t, 2, r.bindCRDSpan.len())
 
 	// fetch the created span for first pod
 	_, span5 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod)
 
 	require.Equal(t, span, span5)
-	require.Len(t, r.bindCRDSpan, 2)
+	require.Equal(t, 2, r.bindCRDSpan.len())
 
 	// remove the created span for first pod
 	r.unbindSpan(pod)
-	require.Len(t, r.bindCRDSpan, 1)
+	require.Equal(t, 1, r.bindCRDSpan.len())
 
 	// fetch the span for second pod
 	_, span6 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod2)
 
 	require.Equal(t, span3, span6)
-	require.Len(t, r.bindCRDSpan, 1)
+	require.Equal(t, 1, r.bindCRDSpan.len())
 
 	// re-create span for first pod
 	_, span7 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod)
 
-	require.Equal(t, span, span7)
-	require.Len(t, r.bindCRDSpan, 2)
+	require.NotEqual(t, span, span7)
+	require.Equal(t, 2, r.bindCRDSpan.len())
 
 }
 
+func Test_getSpan_namespaces(t *testing.T) {
+	labels := map[string]string{
+		WorkloadAnnotation: "myworkload",
+		VersionAnnotation:  "0.0.1",
+		AppAnnotation:      "myapp",
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Labels: labels}}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "production", Labels: labels}}
+
+	r := &WorkloadManager{
+		bindCRDSpan: newSpanCache(100, time.Minute),
+		Tracer:      sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample())).Tracer("trace"),
+	}
+
+	// the same workload instance in another namespace has its own span
+	_, span := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod)
+	_, span2 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod2)
+	require.NotEqual(t, span, span2)
+	require.Equal(t, 2, r.bindCRDSpan.len())
+
+	// unbinding the span in one namespace keeps the span of the other
+	r.unbindSpan(pod)
+	_, span3 := r.getSpan(context.TODO(), &unstructured.Unstructured{}, pod2)
+	require.Equal(t, span2, span3)
+	require.Equal(t, 1, r.bindCRDSpan.len())
+}
+
 func Test_calculateVersion(t *testing.T) {
 	tests := []struct {
 		name string
@@ -15,6 +15,7 @@ const (
 	Version         attribute.Key = attribute.Key("keptn.deployment.version")
 	Namespace       attribute.Key = attribute.Key("keptn.deployment.namespace")
 	Status          attribute.Key = attribute.Key("keptn.deployment.status")
+	PermitCheck     attribute.Key = attribute.Key("keptn.deployment.permit_check")
 )
 
 func CreateSpan(ctx context.Context, crd *unstructured.Unstructured, t trace.Tracer, ns string) (context.Context, trace.Span) {